@@ -18,11 +18,56 @@ import (
 
 func init() {
 	addClientsPaginationFlags(clientsListCmd)
+	clientsListCmd.Flags().BoolP(api.PaginationAll, "", false, "Fetch all matching clients page by page, ignores --limit and --offset")
 	addClientsSearchFlag(clientsListCmd)
+	clientsListCmd.Flags().StringP(
+		config.ClientFields,
+		"",
+		"",
+		"Comma separated list of client fields to fetch and show, e.g. id,name,cpu_model_name,mem_total,os_version",
+	)
+	clientsListCmd.Flags().StringP(
+		config.ClientSort,
+		"",
+		"",
+		"Comma separated list of client fields to sort by, prefix a field with '-' for descending order, e.g. os_family,-name",
+	)
 	clientsCmd.AddCommand(clientsListCmd)
 	clientCmd.Flags().StringP(config.ClientNameFlag, "n", "", "Get client by name")
 	clientCmd.Flags().BoolP("all", "a", false, "Show client info with additional details")
+	noPromptReq := config.GetNoPromptParamReq()
+	clientCmd.Flags().BoolP(noPromptReq.Field, noPromptReq.ShortName, false, "Fail instead of asking to choose a client if the name is ambiguous")
 	clientsCmd.AddCommand(clientCmd)
+	addClientsSearchFlag(clientWatchCmd)
+	clientWatchCmd.Flags().StringP(
+		config.WatchInterval,
+		"",
+		controllers.DefaultWatchInterval.String(),
+		"How often to poll the server for client changes, e.g. 30s or 1m",
+	)
+	clientsCmd.AddCommand(clientWatchCmd)
+	clientMetricsCmd.Flags().StringP(config.ClientNameFlag, "n", "", "Get metrics of the client by name")
+	clientMetricsCmd.Flags().StringP(
+		config.Since,
+		"",
+		controllers.DefaultMetricsSince,
+		"Start of the time range as RFC3339 timestamp or as duration before now, e.g. 2h or 7d",
+	)
+	clientMetricsCmd.Flags().StringP(
+		config.Until,
+		"",
+		"",
+		"End of the time range as RFC3339 timestamp or as duration before now, defaults to now",
+	)
+	clientsCmd.AddCommand(clientMetricsCmd)
+	addClientsSearchFlag(clientStatsCmd)
+	clientStatsCmd.Flags().StringP(
+		config.ClientStatsBy,
+		"",
+		controllers.DefaultClientStatsBy,
+		"Comma separated list of client fields to count the clients by, e.g. os_family,version",
+	)
+	clientsCmd.AddCommand(clientStatsCmd)
 	rootCmd.AddCommand(clientsCmd)
 
 	// see help.go
@@ -95,6 +140,7 @@ var clientCmd = &cobra.Command{
 		clientsController := &controllers.ClientController{
 			Rport:          rportAPI,
 			ClientRenderer: cr,
+			ClientPicker:   newClientPicker(),
 		}
 
 		ctx, cancel := buildContext(context.Background())
@@ -104,6 +150,110 @@ var clientCmd = &cobra.Command{
 	},
 }
 
+var clientWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "watch clients and print an event whenever a client connects, disconnects, appears, disappears or changes its address",
+	Long: `watch clients and print an event whenever a client connects, disconnects, appears, disappears or changes its address.
+Runs until interrupted. With "-o json" every event is printed as a single line of json.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		cwc := &controllers.ClientWatchController{
+			Rport: buildRport(params),
+			ClientEventRenderer: &output.ClientEventRenderer{
+				Writer: os.Stdout,
+				Format: getOutputFormat(),
+			},
+		}
+
+		ctx, cancel, sigs := makeRunContext()
+		defer cancel()
+
+		return cwc.Watch(ctx, params, searchFlags, sigs)
+	},
+}
+
+var clientMetricsCmd = &cobra.Command{
+	Use:   "metrics <ID>",
+	Short: "show the cpu, memory and io usage of a client identified by its id or name",
+	Long: `show the cpu, memory and io usage of a client identified by its id or name, e.g.
+rportcli client metrics --name web1 --since 24h
+requires the monitoring to be enabled on the client`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		clientID := ""
+		if len(args) > 0 {
+			clientID = args[0]
+		}
+
+		cmc := &controllers.ClientMetricsController{
+			Rport: buildRport(params),
+			ClientMetricsRenderer: &output.ClientMetricsRenderer{
+				ColCountCalculator: utils.CalcTerminalColumnsCount,
+				Writer:             os.Stdout,
+				Format:             getOutputFormat(),
+			},
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return cmc.Metrics(ctx, params, clientID)
+	},
+}
+
+var clientStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "count the clients matching the search by the values of one or more client fields",
+	Long: `count the clients matching the search by the values of one or more client fields, e.g.
+rportcli client stats --by os_family,version --search connection_state=connected`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		clientsController := &controllers.ClientController{
+			Rport: buildRport(params),
+			ClientRenderer: &output.ClientRenderer{
+				ColCountCalculator: utils.CalcTerminalColumnsCount,
+				Writer:             os.Stdout,
+				Format:             getOutputFormat(),
+			},
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return clientsController.Stats(ctx, params, searchFlags)
+	},
+}
+
+// newClientPicker returns a picker to choose a client interactively if the name given by the user is ambiguous,
+// no picker is returned if stdin is not a terminal
+func newClientPicker() controllers.ClientPicker {
+	if !utils.IsTerminal(os.Stdin) {
+		return nil
+	}
+
+	return &controllers.SelectClientPicker{
+		Selector: &utils.SelectPrompt{
+			In:  os.Stdin,
+			Out: os.Stderr,
+		},
+	}
+}
+
 func addClientsPaginationFlags(cmd *cobra.Command) {
 	// TODO: why isn't this getting picked up
 	cmd.Flags().IntP(api.PaginationLimit, "", api.ClientsLimitDefault, "Number of clients to fetch")
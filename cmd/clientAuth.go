@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	clientAuthListCmd.Flags().IntP(api.PaginationLimit, "", api.ClientsLimitDefault, "Number of client auth credentials to fetch")
+	clientAuthListCmd.Flags().IntP(api.PaginationOffset, "", 0, "Offset for client auth credentials fetch")
+	clientAuthCmd.AddCommand(clientAuthListCmd)
+	clientAuthCmd.AddCommand(clientAuthCreateCmd)
+
+	clientAuthDeleteCmd.Flags().BoolP(
+		config.ForceDeletion,
+		"f",
+		false,
+		"Delete the credentials even if clients are still using them",
+	)
+	clientAuthCmd.AddCommand(clientAuthDeleteCmd)
+	rootCmd.AddCommand(clientAuthCmd)
+
+	// see help.go
+	clientAuthCmd.SetUsageTemplate(usageTemplate + serverAuthenticationRefer)
+}
+
+var clientAuthCmd = &cobra.Command{
+	Use:   "client-auth [command]",
+	Short: "manage the credentials rport clients use to connect to the server",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var clientAuthListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list all client auth credentials",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientAuthController(params).ClientAuths(ctx, params)
+	},
+}
+
+var clientAuthCreateCmd = &cobra.Command{
+	Use:   "create <ID>",
+	Short: "create client auth credentials with a random password",
+	Long: `creates client auth credentials with a strong random password, e.g.
+rportcli client-auth create web-servers
+the output contains a snippet for the rport.conf of the clients to connect with the new credentials`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientAuthController(params).Create(ctx, params, args[0])
+	},
+}
+
+var clientAuthDeleteCmd = &cobra.Command{
+	Use:   "delete <ID>",
+	Short: "delete client auth credentials",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientAuthController(params).Delete(ctx, params, args[0])
+	},
+}
+
+func createClientAuthController(params *options.ParameterBag) *controllers.ClientAuthController {
+	return &controllers.ClientAuthController{
+		Rport: buildRport(params),
+		ClientAuthRenderer: &output.ClientAuthRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	clientDeleteCmd.Flags().StringP(config.ClientNameFlag, "n", "", "Delete client by name")
+	clientsCmd.AddCommand(clientDeleteCmd)
+
+	clientPruneCmd.Flags().StringP(
+		config.DisconnectedFor,
+		"",
+		"",
+		"[required] Delete clients disconnected for longer than this duration, e.g. 30d or 12h",
+	)
+	addClientsSearchFlag(clientPruneCmd)
+	noPromptReq := config.GetNoPromptParamReq()
+	clientPruneCmd.Flags().BoolP(noPromptReq.Field, noPromptReq.ShortName, false, noPromptReq.Description)
+	clientsCmd.AddCommand(clientPruneCmd)
+}
+
+var clientDeleteCmd = &cobra.Command{
+	Use:   "delete <ID>",
+	Short: "delete a disconnected client identified by its id or name",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		clientID := ""
+		if len(args) > 0 {
+			clientID = args[0]
+		}
+
+		ctx, cancel, sigs := makeRunContext()
+		defer cancel()
+
+		return createClientDeleteController(params, sigs).Delete(ctx, params, clientID)
+	},
+}
+
+var clientPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "delete all clients matching the search which are disconnected for longer than the given duration",
+	Long: `delete all clients matching the search which are disconnected for longer than the given duration, e.g.
+rportcli client prune --disconnected-for 30d --search os_kernel=windows
+the clients to delete are listed and must be confirmed unless --no-prompt is given`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel, sigs := makeRunContext()
+		defer cancel()
+
+		return createClientDeleteController(params, sigs).Prune(ctx, params, searchFlags)
+	},
+}
+
+func createClientDeleteController(params *options.ParameterBag, sigs chan os.Signal) *controllers.ClientDeleteController {
+	return &controllers.ClientDeleteController{
+		Rport: buildRport(params),
+		ClientDeleteRenderer: &output.ClientRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+		PromptReader: &utils.PromptReader{
+			Sc:              bufio.NewScanner(os.Stdin),
+			SigChan:         sigs,
+			PasswordScanner: utils.ReadPassword,
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	clientGroupsCmd.AddCommand(clientGroupListCmd)
+	clientGroupsCmd.AddCommand(clientGroupGetCmd)
+	clientGroupsCmd.AddCommand(clientGroupMembersCmd)
+
+	clientGroupCreateCmd.Flags().StringP(config.Description, "", "", "Description of the client group")
+	addClientsSearchFlag(clientGroupCreateCmd)
+	clientGroupsCmd.AddCommand(clientGroupCreateCmd)
+
+	clientGroupUpdateCmd.Flags().StringP(config.Description, "", "", "New description of the client group")
+	addClientsSearchFlag(clientGroupUpdateCmd)
+	clientGroupsCmd.AddCommand(clientGroupUpdateCmd)
+
+	clientGroupsCmd.AddCommand(clientGroupDeleteCmd)
+	rootCmd.AddCommand(clientGroupsCmd)
+
+	// see help.go
+	clientGroupsCmd.SetUsageTemplate(usageTemplate + serverAuthenticationRefer)
+}
+
+var clientGroupsCmd = &cobra.Command{
+	Use:   "client-group [command]",
+	Short: "manage client groups",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var clientGroupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list all client groups",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientGroupController(params).ClientGroups(ctx)
+	},
+}
+
+var clientGroupGetCmd = &cobra.Command{
+	Use:   "get <ID>",
+	Short: "get the details of a client group including the ids of the clients which belong to it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientGroupController(params).ClientGroup(ctx, args[0])
+	},
+}
+
+var clientGroupMembersCmd = &cobra.Command{
+	Use:   "members <ID>",
+	Short: "list the clients which currently belong to a client group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientGroupController(params).Members(ctx, args[0])
+	},
+}
+
+var clientGroupCreateCmd = &cobra.Command{
+	Use:   "create <ID>",
+	Short: "create a client group from one or more search criteria",
+	Long: `creates a new client group, e.g.
+rportcli client-group create debian-web --description "debian web servers" --search os_family=debian --search name=web*
+all clients matching every given --search key=value pair will belong to the group,
+comma separated values, e.g. --search name=web1,web2, are matched alternatively`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientGroupController(params).Create(ctx, params, args[0], searchFlags)
+	},
+}
+
+var clientGroupUpdateCmd = &cobra.Command{
+	Use:   "update <ID>",
+	Short: "change the description or the search criteria of a client group",
+	Long: `updates an existing client group, e.g.
+rportcli client-group update debian-web --search os_family=debian --search name=www*
+if --search is given, all search criteria of the group are replaced`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientGroupController(params).Update(ctx, params, args[0], searchFlags)
+	},
+}
+
+var clientGroupDeleteCmd = &cobra.Command{
+	Use:   "delete <ID>",
+	Short: "delete a client group, the clients are not affected",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientGroupController(params).Delete(ctx, args[0])
+	},
+}
+
+func createClientGroupController(params *options.ParameterBag) *controllers.ClientGroupController {
+	rportAPI := buildRport(params)
+
+	return &controllers.ClientGroupController{
+		Rport: rportAPI,
+		ClientGroupRenderer: &output.ClientGroupRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+		ClientRenderer: &output.ClientRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+	}
+}
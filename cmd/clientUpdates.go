@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	addClientsSearchFlag(clientUpdatesListCmd)
+	clientUpdatesListCmd.Flags().BoolP(config.UpdatesAvailable, "", false, "Show only clients with available updates")
+	clientUpdatesListCmd.Flags().BoolP(
+		config.SecurityUpdatesAvailable,
+		"",
+		false,
+		"Show only clients with available security updates",
+	)
+	clientUpdatesListCmd.Flags().BoolP(config.RebootPending, "", false, "Show only clients with a pending reboot")
+	clientUpdatesCmd.AddCommand(clientUpdatesListCmd)
+
+	clientUpdatesGetCmd.Flags().StringP(config.ClientNameFlag, "n", "", "Get updates of the client by name")
+	clientUpdatesCmd.AddCommand(clientUpdatesGetCmd)
+
+	clientUpdatesRefreshCmd.Flags().StringP(config.ClientNameFlag, "n", "", "Refresh the update status of the client by name")
+	addClientsSearchFlag(clientUpdatesRefreshCmd)
+	clientUpdatesCmd.AddCommand(clientUpdatesRefreshCmd)
+
+	clientsCmd.AddCommand(clientUpdatesCmd)
+}
+
+var clientUpdatesCmd = &cobra.Command{
+	Use:   "updates [command]",
+	Short: "show and refresh the operating system updates available on clients",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var clientUpdatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the number of available updates and pending reboots of all matching clients",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientUpdatesController(params).Updates(ctx, params, searchFlags)
+	},
+}
+
+var clientUpdatesGetCmd = &cobra.Command{
+	Use:   "get <ID>",
+	Short: "list every available update of a client identified by its id or name",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		clientID := ""
+		if len(args) > 0 {
+			clientID = args[0]
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientUpdatesController(params).UpdateSummaries(
+			ctx,
+			clientID,
+			params.ReadString(config.ClientNameFlag, ""),
+		)
+	},
+}
+
+var clientUpdatesRefreshCmd = &cobra.Command{
+	Use:   "refresh [ID...]",
+	Short: "ask the server to refresh the update status of clients identified by their ids, name or search",
+	Long: `ask the server to refresh the update status of clients identified by their ids, name or search.
+The clients report the new update status asynchronously, use "client updates list" to see it once reported.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createClientUpdatesController(params).Refresh(ctx, params, args, searchFlags)
+	},
+}
+
+func createClientUpdatesController(params *options.ParameterBag) *controllers.ClientUpdatesController {
+	return &controllers.ClientUpdatesController{
+		Rport: buildRport(params),
+		ClientUpdatesRenderer: &output.ClientUpdatesRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+	}
+}
@@ -54,9 +54,13 @@ var executeCmd = &cobra.Command{
 			return err
 		}
 
-		wsClient, err := newWsClient(ctx, params, makeWsCommandURLProvider(params))
-		if err != nil {
-			return err
+		// detached executions are started through the REST api
+		var wsClient *utils.WsClient
+		if !params.ReadBool(config.Detach, false) {
+			wsClient, err = newWsClient(ctx, params, makeWsCommandURLProvider(params))
+			if err != nil {
+				return err
+			}
 		}
 
 		rportAPI := buildRport(params)
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	commandLibraryListCmd.Flags().IntP(api.PaginationLimit, "", api.ClientsLimitDefault, "Number of stored commands to fetch")
+	commandLibraryListCmd.Flags().IntP(api.PaginationOffset, "", 0, "Offset for stored commands fetch")
+	commandLibraryCmd.AddCommand(commandLibraryListCmd)
+	commandLibraryCmd.AddCommand(commandLibraryGetCmd)
+
+	commandLibraryCreateCmd.Flags().StringP(config.Command, "c", "", "[required] Command to store")
+	commandLibraryCreateCmd.Flags().StringP(config.Interpreter, "i", "", "Interpreter/shell name to execute the command with")
+	commandLibraryCmd.AddCommand(commandLibraryCreateCmd)
+
+	commandLibraryUpdateCmd.Flags().StringP(config.NewName, "", "", "New name of the stored command")
+	commandLibraryUpdateCmd.Flags().StringP(config.Command, "c", "", "New command")
+	commandLibraryUpdateCmd.Flags().StringP(config.Interpreter, "i", "", "New interpreter/shell name")
+	commandLibraryCmd.AddCommand(commandLibraryUpdateCmd)
+	commandLibraryCmd.AddCommand(commandLibraryDeleteCmd)
+
+	commandCmd.AddCommand(commandLibraryCmd)
+}
+
+var commandLibraryCmd = &cobra.Command{
+	Use:   "library [command]",
+	Short: "manage the commands stored on the server to be executed by name",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var commandLibraryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the stored commands",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createCommandLibraryController(params).List(ctx, params)
+	},
+}
+
+var commandLibraryGetCmd = &cobra.Command{
+	Use:   "get <NAME|ID>",
+	Short: "show a stored command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createCommandLibraryController(params).Get(ctx, args[0])
+	},
+}
+
+var commandLibraryCreateCmd = &cobra.Command{
+	Use:   "create <NAME>",
+	Short: "store a command to execute it later by name",
+	Long: `stores a command in the command library of the server, e.g.
+rportcli command library create disk-usage -c "df -h /"
+the command can be executed by name afterwards with
+rportcli command execute --from-library disk-usage -n "web*"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createCommandLibraryController(params).Create(ctx, params, args[0])
+	},
+}
+
+var commandLibraryUpdateCmd = &cobra.Command{
+	Use:   "update <NAME|ID>",
+	Short: "change the name, command or interpreter of a stored command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createCommandLibraryController(params).Update(ctx, params, args[0])
+	},
+}
+
+var commandLibraryDeleteCmd = &cobra.Command{
+	Use:   "delete <NAME|ID>",
+	Short: "delete a stored command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createCommandLibraryController(params).Delete(ctx, args[0])
+	},
+}
+
+func createCommandLibraryController(params *options.ParameterBag) *controllers.CommandLibraryController {
+	return &controllers.CommandLibraryController{
+		Rport: buildRport(params),
+		StoredCommandRenderer: &output.StoredCommandRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+func init() {
+	execLogShowCmd.Flags().StringP(
+		config.JobStatus,
+		"",
+		"",
+		"Show only jobs with the given statuses, comma separated, e.g. failed,unknown",
+	)
+	execLogShowCmd.Flags().StringP(
+		config.ClientID,
+		"",
+		"",
+		"Show only jobs of the clients with the given ids or names, comma separated, wildcards (*) are supported",
+	)
+	execLogShowCmd.Flags().BoolP(config.IsFullOutput, "", false, "Show the full job details instead of the output only")
+	execLogCmd.AddCommand(execLogShowCmd)
+
+	execLogCmd.AddCommand(execLogDiffCmd)
+
+	reportReq := config.GetReportParamReq()
+	execLogConvertCmd.Flags().StringSliceP(reportReq.Field, "", nil, reportReq.Description)
+	execLogCmd.AddCommand(execLogConvertCmd)
+
+	rootCmd.AddCommand(execLogCmd)
+}
+
+var execLogCmd = &cobra.Command{
+	Use:   "execlog [command]",
+	Short: "work with the execution logs written by --write-execlog",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var execLogShowCmd = &cobra.Command{
+	Use:   "show <EXECLOG-FILE>",
+	Short: "show who executed the jobs of an execution log and their output",
+	Long: `shows the header of an execution log followed by the output of each client, e.g.
+rportcli execlog show run.yaml --status failed --client "web*"
+shows the output of the failed jobs of the clients with names starting with web.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := readExecLogParams(cmd)
+		return createExecLogController(params).Show(params, args[0])
+	},
+}
+
+var execLogDiffCmd = &cobra.Command{
+	Use:   "diff <BEFORE-EXECLOG-FILE> <AFTER-EXECLOG-FILE>",
+	Short: "show the clients whose status or output changed between two execution logs",
+	Long: `compares the jobs of each client in two execution logs, e.g. a run before a change with a run after it
+rportcli execlog diff before.yaml after.yaml
+shows the status changes and the changes of the output and error output as unified diffs.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := readExecLogParams(cmd)
+		return createExecLogController(params).Diff(args[0], args[1])
+	},
+}
+
+var execLogConvertCmd = &cobra.Command{
+	Use:   "convert <EXECLOG-FILE>",
+	Short: "convert an execution log to test reports for CI systems",
+	Long: `writes every job of an execution log as a test case named after its client, e.g.
+rportcli execlog convert run.yaml --report junit=report.xml --report tap=report.tap
+failed jobs are failures, timed out jobs are errors in JUnit XML, only successful jobs are ok in TAP.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reportValues, err := cmd.Flags().GetStringSlice(config.Report)
+		if err != nil {
+			return err
+		}
+
+		return createExecLogController(readExecLogParams(cmd)).Convert(args[0], reportValues)
+	},
+}
+
+// readExecLogParams reads the flags only, execution logs are read without the server, so the config file and
+// the API URL are not needed
+func readExecLogParams(cmd *cobra.Command) *options.ParameterBag {
+	return options.New(config.CreateFlagValuesProvider(cmd.Flags()))
+}
+
+func createExecLogController(params *options.ParameterBag) *controllers.ExecLogController {
+	return &controllers.ExecLogController{
+		ExecLogRenderer: &output.ExecLogRenderer{
+			Writer: os.Stdout,
+			Format: getOutputFormat(),
+		},
+		JobRenderer: &output.JobRenderer{
+			Writer:       os.Stdout,
+			Format:       getOutputFormat(),
+			IsFullOutput: params.ReadBool(config.IsFullOutput, false),
+		},
+	}
+}
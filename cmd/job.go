@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	addJobClientFlags(jobListCmd)
+	jobListCmd.Flags().StringP(config.JobStatus, "", "", "Show only jobs with the given status, e.g. successful, failed or running")
+	jobListCmd.Flags().StringP(config.CreatedBy, "", "", "Show only jobs created by the given user")
+	jobListCmd.Flags().StringP(
+		config.Since,
+		"",
+		"",
+		"Show only jobs started after the RFC3339 timestamp or the duration before now, e.g. 2h or 7d",
+	)
+	jobListCmd.Flags().StringP(
+		config.Until,
+		"",
+		"",
+		"Show only jobs started before the RFC3339 timestamp or the duration before now",
+	)
+	jobListCmd.Flags().IntP(api.PaginationLimit, "", api.ClientsLimitDefault, "Number of jobs to fetch")
+	jobListCmd.Flags().IntP(api.PaginationOffset, "", 0, "Offset for jobs fetch")
+	jobCmd.AddCommand(jobListCmd)
+
+	addJobClientFlags(jobGetCmd)
+	jobGetCmd.Flags().BoolP(config.IsFullOutput, "", false, "Show the full job details instead of the output only")
+	jobCmd.AddCommand(jobGetCmd)
+
+	jobWaitCmd.Flags().StringP(
+		config.WatchInterval,
+		"",
+		controllers.DefaultJobWaitInterval.String(),
+		"Interval to poll the job status, e.g. 5s or 1m",
+	)
+	jobWaitCmd.Flags().BoolP(config.IsFullOutput, "", false, "Show the full job details instead of the output only")
+	jobWaitCmd.Flags().StringP(config.WriteExecLog, "", "", "Write a log of the execution output")
+	aggregateReq := config.GetAggregateParamReq()
+	jobWaitCmd.Flags().BoolP(aggregateReq.Field, "", false, aggregateReq.Description)
+	failThresholdReq := config.GetFailThresholdParamReq()
+	jobWaitCmd.Flags().StringP(failThresholdReq.Field, "", "", failThresholdReq.Description)
+	noPromptReq := config.GetNoPromptParamReq()
+	jobWaitCmd.Flags().BoolP(noPromptReq.Field, noPromptReq.ShortName, false, noPromptReq.Description)
+	jobCmd.AddCommand(jobWaitCmd)
+
+	rootCmd.AddCommand(jobCmd)
+
+	// see help.go
+	jobCmd.SetUsageTemplate(usageTemplate + serverAuthenticationRefer)
+}
+
+func addJobClientFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(config.ClientID, "c", "", "Client id to show the command history of")
+	cmd.Flags().StringP(config.ClientNameFlag, "n", "", "Client name to show the command history of")
+	noPromptReq := config.GetNoPromptParamReq()
+	cmd.Flags().BoolP(noPromptReq.Field, noPromptReq.ShortName, false, "Fail instead of asking to choose a client if the name is ambiguous")
+}
+
+var jobCmd = &cobra.Command{
+	Use:   "job [command]",
+	Short: "show the history of executed commands and scripts",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var jobListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the multi-client jobs or with --client or --name the jobs of a single client",
+	Long: `lists the jobs created by command and script execution, newest first, e.g.
+rportcli job list --status failed --since 24h
+lists the commands and scripts executed on several clients at once within the last day. Use --client or --name
+to list the jobs of a single client instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createJobController(params).List(ctx, params)
+	},
+}
+
+var jobGetCmd = &cobra.Command{
+	Use:   "get <JID>",
+	Short: "show the output of a multi-client job or with --client or --name of a single client job",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createJobController(params).Get(ctx, params, args[0])
+	},
+}
+
+var jobWaitCmd = &cobra.Command{
+	Use:     "wait <MULTI-JOB-ID>",
+	Aliases: []string{"attach"},
+	Short:   "wait for a multi-client job to finish and show its output like an attached execution",
+	Long: `waits for a multi-client job to finish on all clients and shows the output of each client as soon as it has
+finished, e.g. the job started by
+rportcli command execute --detach -d <CLIENT-IDS> -c "apt-get -y upgrade"
+use --write-execlog to write the execution log like command execute does.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel, sigs := makeRunContext()
+		defer cancel()
+
+		promptReader := &utils.PromptReader{
+			Sc:              bufio.NewScanner(os.Stdin),
+			SigChan:         sigs,
+			PasswordScanner: utils.ReadPassword,
+		}
+
+		return createJobController(params).Wait(ctx, params, args[0], promptReader, sigs)
+	},
+}
+
+func createJobController(params *options.ParameterBag) *controllers.JobController {
+	return &controllers.JobController{
+		Rport: buildRport(params),
+		JobHistoryRenderer: &output.JobHistoryRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+		JobRenderer: &output.JobRenderer{
+			Writer:       os.Stdout,
+			Format:       getOutputFormat(),
+			IsFullOutput: params.ReadBool(config.IsFullOutput, false),
+			Aggregate:    params.ReadBool(config.Aggregate, false),
+		},
+		ClientPicker: newClientPicker(),
+	}
+}
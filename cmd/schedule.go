@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	scheduleListCmd.Flags().IntP(api.PaginationLimit, "", api.ClientsLimitDefault, "Number of schedules to fetch")
+	scheduleListCmd.Flags().IntP(api.PaginationOffset, "", 0, "Offset for schedules fetch")
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleGetCmd)
+
+	config.DefineCommandInputs(scheduleCreateCmd, config.GetScheduleParamReqs())
+	addClientsSearchFlag(scheduleCreateCmd)
+	scheduleCmd.AddCommand(scheduleCreateCmd)
+
+	config.DefineCommandInputs(scheduleUpdateCmd, config.GetScheduleUpdateParamReqs())
+	addClientsSearchFlag(scheduleUpdateCmd)
+	scheduleCmd.AddCommand(scheduleUpdateCmd)
+
+	scheduleCmd.AddCommand(scheduleDeleteCmd)
+	scheduleCmd.AddCommand(scheduleRunNowCmd)
+
+	rootCmd.AddCommand(scheduleCmd)
+
+	// see help.go
+	scheduleCmd.SetUsageTemplate(usageTemplate + serverAuthenticationRefer)
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule [command]",
+	Short: "manage the commands executed on clients by the server on a recurring schedule",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the schedules",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScheduleController(params, cmd, nil).List(ctx, params)
+	},
+}
+
+var scheduleGetCmd = &cobra.Command{
+	Use:   "get <NAME|ID>",
+	Short: "show a schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScheduleController(params, cmd, nil).Get(ctx, args[0])
+	},
+}
+
+var scheduleCreateCmd = &cobra.Command{
+	Use:   "create <NAME>",
+	Short: "create a schedule to execute a command on clients recurringly",
+	Long: `creates a schedule executing a command on clients whenever the cron expression fires, e.g.
+rportcli schedule create nightly-cleanup --cron "0 3 * * *" -n "web*" -c "rm -rf /tmp/cache"
+takes the same targeting and execution flags as command execute. The cron expression is validated locally and its
+next fire times are shown, they have to be confirmed unless --no-prompt is given. The server evaluates the cron
+expression in UTC. Clients given by --names or --search are resolved to the ids of the currently connected clients.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScheduleCommand(cmd, config.GetScheduleParamReqs(), func(
+			ctx context.Context,
+			params *options.ParameterBag,
+			sc *controllers.ScheduleController,
+		) error {
+			return sc.Create(ctx, params, args[0])
+		})
+	},
+}
+
+var scheduleUpdateCmd = &cobra.Command{
+	Use:   "update <NAME|ID>",
+	Short: "change the name, cron expression, targets or command of a schedule",
+	Long: `changes only the values given by the flags, e.g.
+rportcli schedule update nightly-cleanup --cron "30 2 * * *" --is_sudo=false
+any of the targeting flags replaces all targets of the schedule.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScheduleCommand(cmd, config.GetScheduleUpdateParamReqs(), func(
+			ctx context.Context,
+			params *options.ParameterBag,
+			sc *controllers.ScheduleController,
+		) error {
+			return sc.Update(ctx, params, args[0])
+		})
+	},
+}
+
+var scheduleDeleteCmd = &cobra.Command{
+	Use:   "delete <NAME|ID>",
+	Short: "delete a schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScheduleController(params, cmd, nil).Delete(ctx, args[0])
+	},
+}
+
+var scheduleRunNowCmd = &cobra.Command{
+	Use:   "run-now <NAME|ID>",
+	Short: "execute the command of a schedule right away",
+	Long: `starts the command of a schedule on its clients right away without waiting for the next fire time and
+prints the multi job id, use 'rportcli job wait <MULTI-JOB-ID>' to get the results.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScheduleController(params, cmd, nil).RunNow(ctx, args[0])
+	},
+}
+
+// runScheduleCommand loads the parameters like command execute does, including the --search flags and
+// prompting for missing values, and runs the action with a controller able to ask for confirmation
+func runScheduleCommand(
+	cmd *cobra.Command,
+	reqs []config.ParameterRequirement,
+	action func(ctx context.Context, params *options.ParameterBag, sc *controllers.ScheduleController) error,
+) error {
+	ctx, cancel, sigs := makeRunContext()
+	defer cancel()
+
+	promptReader := &utils.PromptReader{
+		Sc:              bufio.NewScanner(os.Stdin),
+		SigChan:         sigs,
+		PasswordScanner: utils.ReadPassword,
+	}
+
+	var injected map[string]string
+	if len(searchFlags) > 0 {
+		injected = map[string]string{"combined-search": strings.Join(searchFlags, "&")}
+	}
+	params, err := loadParams(cmd, reqs, promptReader, injected)
+	if err != nil {
+		return err
+	}
+
+	return action(ctx, params, createScheduleController(params, cmd, promptReader))
+}
+
+func createScheduleController(
+	params *options.ParameterBag,
+	cmd *cobra.Command,
+	promptReader config.PromptReader,
+) *controllers.ScheduleController {
+	return &controllers.ScheduleController{
+		Rport: buildRport(params),
+		ScheduleRenderer: &output.ScheduleRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+		JobRenderer: &output.JobRenderer{
+			Writer: os.Stdout,
+			Format: getOutputFormat(),
+		},
+		PromptReader: promptReader,
+		FlagsChecker: config.CreateUsedFlagsChecker(cmd.Flags()),
+	}
+}
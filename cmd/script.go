@@ -54,7 +54,20 @@ var executeScript = &cobra.Command{
 			return err
 		}
 
-		wsClient, err := newWsClient(ctx, params, makeWsScriptsURLProvider(params))
+		// detached executions are started through the REST api
+		var wsClient *utils.WsClient
+		if !params.ReadBool(config.Detach, false) {
+			wsClient, err = newWsClient(ctx, params, makeWsScriptsURLProvider(params))
+			if err != nil {
+				return err
+			}
+		}
+
+		yamlFiles, err := cmd.Flags().GetStringSlice(config.ReadYAML)
+		if err != nil {
+			return err
+		}
+		explicitParams, err := config.NewExplicitParamsChecker(cmd.Flags(), yamlFiles)
 		if err != nil {
 			return err
 		}
@@ -63,6 +76,7 @@ var executeScript = &cobra.Command{
 
 		cmdExecutor := &controllers.ScriptsController{
 			ExecutionHelper: newExecutionHelper(params, wsClient, rportAPI),
+			ExplicitParams:  explicitParams,
 		}
 
 		err = cmdExecutor.Start(ctx, params, promptReader, nil)
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/controllers"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+func init() {
+	scriptLibraryListCmd.Flags().IntP(api.PaginationLimit, "", api.ClientsLimitDefault, "Number of stored scripts to fetch")
+	scriptLibraryListCmd.Flags().IntP(api.PaginationOffset, "", 0, "Offset for stored scripts fetch")
+	scriptLibraryCmd.AddCommand(scriptLibraryListCmd)
+	scriptLibraryCmd.AddCommand(scriptLibraryGetCmd)
+
+	scriptLibraryCreateCmd.Flags().StringP(config.Script, "s", "", "Path to the script file to store")
+	scriptLibraryCreateCmd.Flags().StringP(config.EmbeddedScript, "c", "", "Script content to store instead of a file")
+	defineStoredScriptFlags(scriptLibraryCreateCmd.Flags(), "")
+	scriptLibraryCmd.AddCommand(scriptLibraryCreateCmd)
+
+	scriptLibraryUpdateCmd.Flags().StringP(config.NewName, "", "", "New name of the stored script")
+	scriptLibraryUpdateCmd.Flags().StringP(config.Script, "s", "", "Path to the file with the new script")
+	scriptLibraryUpdateCmd.Flags().StringP(config.EmbeddedScript, "c", "", "New script content")
+	defineStoredScriptFlags(scriptLibraryUpdateCmd.Flags(), "New ")
+	scriptLibraryCmd.AddCommand(scriptLibraryUpdateCmd)
+	scriptLibraryCmd.AddCommand(scriptLibraryEditCmd)
+	scriptLibraryCmd.AddCommand(scriptLibraryDeleteCmd)
+
+	scriptCmd.AddCommand(scriptLibraryCmd)
+}
+
+func defineStoredScriptFlags(flags *pflag.FlagSet, descPrefix string) {
+	flags.StringP(config.Interpreter, "i", "", descPrefix+"Interpreter to execute the script with")
+	flags.StringP(config.Cwd, "w", "", descPrefix+"Working directory to execute the script in")
+	flags.BoolP(config.IsSudo, "u", false, descPrefix+"Execute the script with sudo")
+}
+
+var scriptLibraryCmd = &cobra.Command{
+	Use:   "library [command]",
+	Short: "manage the scripts stored on the server to be executed by name",
+	Args:  cobra.ArbitraryArgs,
+}
+
+var scriptLibraryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the stored scripts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScriptLibraryController(params, cmd.Flags()).List(ctx, params)
+	},
+}
+
+var scriptLibraryGetCmd = &cobra.Command{
+	Use:   "get <NAME|ID>",
+	Short: "show a stored script",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScriptLibraryController(params, cmd.Flags()).Get(ctx, args[0])
+	},
+}
+
+var scriptLibraryCreateCmd = &cobra.Command{
+	Use:   "create <NAME>",
+	Short: "store a script to execute it later by name",
+	Long: `stores a script in the script library of the server, e.g.
+rportcli script library create cleanup -s cleanup.sh -u
+the script can be executed by name afterwards with
+rportcli script execute --from-library cleanup -n "web*"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScriptLibraryController(params, cmd.Flags()).Create(ctx, params, args[0])
+	},
+}
+
+var scriptLibraryUpdateCmd = &cobra.Command{
+	Use:   "update <NAME|ID>",
+	Short: "change the name, script, interpreter, cwd or sudo flag of a stored script",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScriptLibraryController(params, cmd.Flags()).Update(ctx, params, args[0])
+	},
+}
+
+var scriptLibraryEditCmd = &cobra.Command{
+	Use:   "edit <NAME|ID>",
+	Short: "edit a stored script in $EDITOR and upload it when saved",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScriptLibraryController(params, cmd.Flags()).Edit(ctx, args[0])
+	},
+}
+
+var scriptLibraryDeleteCmd = &cobra.Command{
+	Use:   "delete <NAME|ID>",
+	Short: "delete a stored script",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := config.LoadParamsFromFileAndEnv(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := buildContext(context.Background())
+		defer cancel()
+
+		return createScriptLibraryController(params, cmd.Flags()).Delete(ctx, args[0])
+	},
+}
+
+func createScriptLibraryController(params *options.ParameterBag, flags *pflag.FlagSet) *controllers.ScriptLibraryController {
+	return &controllers.ScriptLibraryController{
+		Rport: buildRport(params),
+		StoredScriptRenderer: &output.StoredScriptRenderer{
+			ColCountCalculator: utils.CalcTerminalColumnsCount,
+			Writer:             os.Stdout,
+			Format:             getOutputFormat(),
+		},
+		FlagsChecker: config.CreateUsedFlagsChecker(flags),
+		Editor:       utils.OpenEditor,
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	options "github.com/breathbath/go_utils/v2/pkg/config"
 
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
@@ -20,6 +21,10 @@ import (
 
 func init() {
 	addClientsPaginationFlags(tunnelListCmd)
+	// tunnels of all matching clients are listed, the flags are kept to not break existing scripts
+	for _, paginationFlag := range []string{api.PaginationLimit, api.PaginationOffset} {
+		_ = tunnelListCmd.Flags().MarkDeprecated(paginationFlag, "tunnels of all matching clients are listed")
+	}
 	addClientsSearchFlag(tunnelListCmd)
 	tunnelListCmd.Flags().StringP(config.ClientNameFlag, "n", "", "Get tunnels of a client by name")
 	tunnelListCmd.Flags().StringP(config.ClientID, "c", "", "Get tunnels of a client by client id")
@@ -133,6 +138,7 @@ func createTunnelController(params *options.ParameterBag) *controllers.TunnelCon
 		Rport:          rportAPI,
 		TunnelRenderer: tr,
 		IPProvider:     rportAPI,
+		ClientPicker:   newClientPicker(),
 	}
 }
 
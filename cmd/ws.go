@@ -92,13 +92,24 @@ func newExecutionHelper(params *options.ParameterBag,
 	rportAPI *api.Rport) (helper *controllers.ExecutionHelper) {
 	isFullJobOutput := params.ReadBool(config.IsFullOutput, false)
 	helper = &controllers.ExecutionHelper{
-		ReadWriter: wsc,
 		JobRenderer: &output.JobRenderer{
 			Writer:       os.Stdout,
 			Format:       getOutputFormat(),
 			IsFullOutput: isFullJobOutput,
+			Aggregate:    params.ReadBool(config.Aggregate, false),
 		},
 		Rport: rportAPI,
 	}
+	// a nil client must not end up as a non-nil interface value
+	if wsc != nil {
+		helper.ReadWriter = wsc
+		helper.NewReadWriter = func(ctx context.Context) (controllers.ReadWriter, error) {
+			retryWsc, err := newWsClient(ctx, params, wsc.WsURLBuilder)
+			if err != nil {
+				return nil, err
+			}
+			return retryWsc, nil
+		}
+	}
 	return helper
 }
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	url2 "net/url"
+
+	"github.com/breathbath/go_utils/v2/pkg/url"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	ClientAuthsURL = "/api/v1/clients-auth"
+	ClientAuthURL  = "/api/v1/clients-auth/%s"
+)
+
+type ClientAuthsResponse struct {
+	Data []*models.ClientAuth
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more client auth credentials than returned for the given pagination
+func (car *ClientAuthsResponse) Truncated(pagination Pagination) bool {
+	return car.Meta.Count > pagination.Offset+len(car.Data)
+}
+
+func (rp *Rport) ClientAuths(ctx context.Context, pagination Pagination) (*ClientAuthsResponse, error) {
+	u, err := url2.Parse(url.JoinURL(rp.BaseURL, ClientAuthsURL))
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	pagination.Apply(q)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	car := &ClientAuthsResponse{}
+	_, err = rp.CallBaseClient(req, car)
+	if err != nil {
+		return nil, err
+	}
+
+	return car, nil
+}
+
+func (rp *Rport) CreateClientAuth(ctx context.Context, auth *models.ClientAuth) error {
+	buf := &bytes.Buffer{}
+	err := json.NewEncoder(buf).Encode(auth)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.JoinURL(rp.BaseURL, ClientAuthsURL), buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = rp.CallBaseClient(req, nil)
+
+	return err
+}
+
+// DeleteClientAuth deletes client auth credentials, with force the credentials are deleted even if clients use them
+func (rp *Rport) DeleteClientAuth(ctx context.Context, id string, force bool) error {
+	u, err := url2.Parse(url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientAuthURL, url2.PathEscape(id))))
+	if err != nil {
+		return err
+	}
+	if force {
+		q := u.Query()
+		q.Set("force", "true")
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rp.CallBaseClient(req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected response code %d, %d is expected", resp.StatusCode, http.StatusNoContent)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestClientAuths(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ClientAuthsURL, r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("page[limit]"))
+		assert.Equal(t, "20", r.URL.Query().Get("page[offset]"))
+		e := json.NewEncoder(rw).Encode(ClientAuthsResponse{
+			Data: []*models.ClientAuth{{ID: "web", Password: "secret"}},
+			Meta: ClientsMeta{Count: 31},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	pagination := Pagination{Limit: 10, Offset: 20}
+	resp, err := cl.ClientAuths(context.Background(), pagination)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.ClientAuth{{ID: "web", Password: "secret"}}, resp.Data)
+	assert.True(t, resp.Truncated(pagination))
+}
+
+func TestCreateClientAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, ClientAuthsURL, r.URL.String())
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id":"web","password":"secret"}`, string(body))
+		rw.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	err := cl.CreateClientAuth(context.Background(), &models.ClientAuth{ID: "web", Password: "secret"})
+	require.NoError(t, err)
+}
+
+func TestDeleteClientAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, ClientAuthsURL+"/web?force=true", r.URL.String())
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	err := cl.DeleteClientAuth(context.Background(), "web", true)
+	require.NoError(t, err)
+}
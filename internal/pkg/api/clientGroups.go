@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	url2 "net/url"
+	"strings"
+
+	"github.com/breathbath/go_utils/v2/pkg/url"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	ClientGroupsURL = "/api/v1/client-groups"
+	ClientGroupURL  = "/api/v1/client-groups/%s"
+)
+
+// clientGroupParamAliases maps the keys accepted by the clients search to the param names of a client group
+var clientGroupParamAliases = map[string]string{
+	"id":   "client_id",
+	"tags": "tag",
+}
+
+type ClientGroupsResponse struct {
+	Data []*models.ClientGroup
+}
+
+type ClientGroupResponse struct {
+	Data *models.ClientGroup
+}
+
+// NewClientGroupParamsFromFilters converts search filters, e.g. as returned by NewFilterFromKVStrings, to client group
+// params. Comma separated values are treated as alternatives, as in the clients search.
+func NewClientGroupParamsFromFilters(f Filters) models.ClientGroupParams {
+	params := make(models.ClientGroupParams, len(f))
+	for key, value := range f {
+		if value == "" {
+			continue
+		}
+		if alias, ok := clientGroupParamAliases[key]; ok {
+			key = alias
+		}
+		values := strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+		params[key] = values
+	}
+
+	return params
+}
+
+func (rp *Rport) ClientGroups(ctx context.Context) (cgr *ClientGroupsResponse, err error) {
+	var req *http.Request
+	req, err = http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		url.JoinURL(rp.BaseURL, ClientGroupsURL),
+		nil,
+	)
+	if err != nil {
+		return
+	}
+
+	cgr = &ClientGroupsResponse{}
+	_, err = rp.CallBaseClient(req, cgr)
+
+	return
+}
+
+func (rp *Rport) ClientGroup(ctx context.Context, id string) (*models.ClientGroup, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientGroupURL, url2.PathEscape(id))),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cgr := &ClientGroupResponse{}
+	_, err = rp.CallBaseClient(req, cgr)
+	if err != nil {
+		return nil, err
+	}
+
+	return cgr.Data, nil
+}
+
+func (rp *Rport) CreateClientGroup(ctx context.Context, group *models.ClientGroup) error {
+	return rp.sendClientGroup(ctx, http.MethodPost, url.JoinURL(rp.BaseURL, ClientGroupsURL), group)
+}
+
+func (rp *Rport) UpdateClientGroup(ctx context.Context, group *models.ClientGroup) error {
+	return rp.sendClientGroup(ctx, http.MethodPut, url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientGroupURL, url2.PathEscape(group.ID))), group)
+}
+
+func (rp *Rport) sendClientGroup(ctx context.Context, method, targetURL string, group *models.ClientGroup) error {
+	// client ids are calculated by the server and cannot be changed
+	groupToSend := *group
+	groupToSend.ClientIDs = nil
+
+	buf := &bytes.Buffer{}
+	err := json.NewEncoder(buf).Encode(groupToSend)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, targetURL, buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = rp.CallBaseClient(req, nil)
+
+	return err
+}
+
+func (rp *Rport) DeleteClientGroup(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientGroupURL, url2.PathEscape(id))),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rp.CallBaseClient(req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected response code %d, %d is expected", resp.StatusCode, http.StatusNoContent)
+	}
+
+	return nil
+}
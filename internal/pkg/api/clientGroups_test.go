@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+var clientGroupStub = &models.ClientGroup{
+	ID:          "debian",
+	Description: "all debian clients",
+	Params: models.ClientGroupParams{
+		"os_family": {"debian"},
+		"name":      {"web*", "db*"},
+	},
+	ClientIDs: []string{"123", "124"},
+}
+
+func TestClientGroupsList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, ClientGroupsURL, r.URL.String())
+		e := json.NewEncoder(rw).Encode(ClientGroupsResponse{Data: []*models.ClientGroup{clientGroupStub}})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	resp, err := cl.ClientGroups(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.ClientGroup{clientGroupStub}, resp.Data)
+}
+
+func TestClientGroupGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, ClientGroupsURL+"/debian", r.URL.String())
+		e := json.NewEncoder(rw).Encode(ClientGroupResponse{Data: clientGroupStub})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	group, err := cl.ClientGroup(context.Background(), "debian")
+	require.NoError(t, err)
+
+	assert.Equal(t, clientGroupStub, group)
+}
+
+func TestClientGroupCreateAndUpdate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedMethod string
+		expectedURL    string
+		call           func(rp *Rport) error
+	}{
+		{
+			name:           "create",
+			expectedMethod: http.MethodPost,
+			expectedURL:    ClientGroupsURL,
+			call: func(rp *Rport) error {
+				return rp.CreateClientGroup(context.Background(), clientGroupStub)
+			},
+		},
+		{
+			name:           "update",
+			expectedMethod: http.MethodPut,
+			expectedURL:    ClientGroupsURL + "/debian",
+			call: func(rp *Rport) error {
+				return rp.UpdateClientGroup(context.Background(), clientGroupStub)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.expectedMethod, r.Method)
+				assert.Equal(t, tc.expectedURL, r.URL.String())
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(
+					t,
+					`{"id":"debian","description":"all debian clients","params":{"name":["web*","db*"],"os_family":["debian"]}}`,
+					string(body),
+				)
+				rw.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			err := tc.call(New(srv.URL, nil))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClientGroupDelete(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, ClientGroupsURL+"/web%20servers%2Feu", r.URL.String())
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	err := cl.DeleteClientGroup(context.Background(), "web servers/eu")
+	require.NoError(t, err)
+}
+
+func TestNewClientGroupParamsFromFilters(t *testing.T) {
+	filters, err := NewFilterFromKVStrings([]string{"os_family=debian", "tags=web, db", "id=123"})
+	require.NoError(t, err)
+
+	actualParams := NewClientGroupParamsFromFilters(filters)
+
+	assert.Equal(t, models.ClientGroupParams{
+		"os_family": {"debian"},
+		"tag":       {"web", "db"},
+		"client_id": {"123"},
+	}, actualParams)
+}
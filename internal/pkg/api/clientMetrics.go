@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	url2 "net/url"
+	"time"
+
+	"github.com/breathbath/go_utils/v2/pkg/url"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const ClientMetricsURL = "/api/v1/clients/%s/metrics"
+
+type ClientMetricsResponse struct {
+	Data []*models.ClientMetrics
+}
+
+// ClientMetrics fetches the cpu, memory and io usage measured by the monitoring of the client within the time range
+func (rp *Rport) ClientMetrics(ctx context.Context, clientID string, since, until time.Time) ([]*models.ClientMetrics, error) {
+	u, err := url2.Parse(url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientMetricsURL, url2.PathEscape(clientID))))
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("filter[timestamp][since]", since.UTC().Format(time.RFC3339))
+	q.Set("filter[timestamp][until]", until.UTC().Format(time.RFC3339))
+	q.Set("fields[metrics]", "timestamp,cpu_usage_percent,memory_usage_percent,io_usage_percent")
+	q.Set("sort", "timestamp")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cmr := &ClientMetricsResponse{}
+	_, err = rp.CallBaseClient(req, cmr)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmr.Data, nil
+}
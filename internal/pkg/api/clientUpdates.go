@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/breathbath/go_utils/v2/pkg/url"
+)
+
+const ClientUpdatesStatusRefreshURL = "/api/v1/clients/%s/updates-status/refresh"
+
+// ClientUpdatesFields are the client fields needed to render the updates report of clients
+var ClientUpdatesFields = Fields{"id", "name", "updates_status"}
+
+// RefreshClientUpdatesStatus asks the server to re-scan the available updates on the client,
+// the new status becomes available after the client has reported it back
+func (rp *Rport) RefreshClientUpdatesStatus(ctx context.Context, clientID string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientUpdatesStatusRefreshURL, clientID)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rp.CallBaseClient(req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected response code %d, %d is expected", resp.StatusCode, http.StatusNoContent)
+	}
+
+	return nil
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	url2 "net/url"
+	"strings"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
 
 	"github.com/breathbath/go_utils/v2/pkg/url"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -18,18 +20,150 @@ const (
 	ClientsLimitMax     = 500
 )
 
+type ClientsMeta struct {
+	Count int `json:"count"`
+}
+
 type ClientsResponse struct {
 	Data []*models.Client
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more clients matching the filters than returned for the given pagination
+func (cr *ClientsResponse) Truncated(pagination Pagination) bool {
+	return cr.Meta.Count > pagination.Offset+len(cr.Data)
+}
+
+// ClientsPageIterator fetches clients page by page following page[offset] until all matching clients are received
+type ClientsPageIterator struct {
+	rp         *Rport
+	filters    Filters
+	pagination Pagination
+	fields     Fields
+	sort       Sort
+	total      int
+	fetched    int
+	done       bool
+}
+
+func (rp *Rport) NewClientsPageIterator(filters Filters, pageSize int) *ClientsPageIterator {
+	return rp.NewClientsPageIteratorWithFields(filters, pageSize, DefaultClientFields, nil)
+}
+
+func (rp *Rport) NewClientsPageIteratorWithFields(filters Filters, pageSize int, fields Fields, sort Sort) *ClientsPageIterator {
+	return &ClientsPageIterator{
+		rp:         rp,
+		filters:    filters,
+		pagination: NewPaginationWithLimit(pageSize),
+		fields:     fields,
+		sort:       sort,
+	}
+}
+
+// Next returns the next page of clients, hasPage is false when all pages are read
+func (it *ClientsPageIterator) Next(ctx context.Context) (clients []*models.Client, hasPage bool, err error) {
+	if it.done {
+		return nil, false, nil
+	}
+
+	cr, err := it.rp.ClientsWithFields(ctx, it.pagination, it.filters, it.fields, it.sort)
+	if err != nil {
+		return nil, false, err
+	}
+
+	it.total = cr.Meta.Count
+	it.fetched += len(cr.Data)
+	it.pagination.Offset += len(cr.Data)
+
+	// a short page is the last one, servers not sending the total count are covered by this too
+	if len(cr.Data) < it.pagination.Limit || (it.total > 0 && it.pagination.Offset >= it.total) {
+		it.done = true
+	}
+	if len(cr.Data) == 0 {
+		return nil, false, nil
+	}
+
+	return cr.Data, true, nil
+}
+
+// Truncated tells if fewer clients were fetched than the server reported to be matching
+func (it *ClientsPageIterator) Truncated() bool {
+	return it.total > it.fetched
+}
+
+func (it *ClientsPageIterator) Total() int {
+	return it.total
+}
+
+// AllClients fetches all clients matching the filters page by page
+func (rp *Rport) AllClients(ctx context.Context, filters Filters) ([]*models.Client, error) {
+	return rp.AllClientsWithFields(ctx, filters, DefaultClientFields, nil)
+}
+
+// AllClientsWithFields fetches all clients matching the filters page by page with only the given fields
+func (rp *Rport) AllClientsWithFields(ctx context.Context, filters Filters, fields Fields, sort Sort) ([]*models.Client, error) {
+	it := rp.NewClientsPageIteratorWithFields(filters, ClientsLimitMax, fields, sort)
+
+	clients := make([]*models.Client, 0)
+	for {
+		page, hasPage, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPage {
+			break
+		}
+		clients = append(clients, page...)
+	}
+
+	if it.Truncated() {
+		logrus.Warnf("received only %d of %d matching clients, the client list has changed while fetching it", len(clients), it.Total())
+	}
+
+	return clients, nil
+}
+
+// DefaultClientFields are the client fields requested unless the caller asks for specific fields
+var DefaultClientFields = Fields{
+	"id", "name", "timezone", "tunnels", "address", "hostname", "os_kernel", "connection_state", "disconnected_at",
+}
+
+// Fields selects the client fields returned by the server
+type Fields []string
+
+func (f Fields) Apply(q url2.Values) {
+	q.Set("fields[clients]", strings.Join(f, ","))
+}
+
+// Sort lists the fields to order clients by, a field prefixed by '-' is sorted in descending order
+type Sort []string
+
+func (s Sort) Apply(q url2.Values) {
+	for _, field := range s {
+		q.Add("sort", field)
+	}
 }
 
 func (rp *Rport) Clients(ctx context.Context, pagination Pagination, filters Filters) (cr *ClientsResponse, err error) {
+	return rp.ClientsWithFields(ctx, pagination, filters, DefaultClientFields, nil)
+}
+
+// ClientsWithFields fetches a page of clients with only the given fields in the given sort order
+func (rp *Rport) ClientsWithFields(
+	ctx context.Context,
+	pagination Pagination,
+	filters Filters,
+	fields Fields,
+	sort Sort,
+) (cr *ClientsResponse, err error) {
 	var req *http.Request
 	u, err := url2.Parse(url.JoinURL(rp.BaseURL, ClientsURL))
 	if err != nil {
 		return nil, err
 	}
 	q := u.Query()
-	q.Set("fields[clients]", "id,name,timezone,tunnels,address,hostname,os_kernel,connection_state,disconnected_at")
+	fields.Apply(q)
+	sort.Apply(q)
 	pagination.Apply(q)
 	filters.Apply(q)
 	u.RawQuery = q.Encode()
@@ -81,3 +215,27 @@ func (rp *Rport) Client(ctx context.Context, id string) (*models.Client, error)
 
 	return cr.Data, nil
 }
+
+// DeleteClient deletes a disconnected client, the server refuses to delete connected clients
+func (rp *Rport) DeleteClient(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		url.JoinURL(rp.BaseURL, fmt.Sprintf(ClientURL, id)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rp.CallBaseClient(req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected response code %d, %d is expected", resp.StatusCode, http.StatusNoContent)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
@@ -130,3 +131,87 @@ func TestClientGet(t *testing.T) {
 
 	assert.Equal(t, clientsStub[0], client)
 }
+
+func TestAllClientsFollowsPages(t *testing.T) {
+	allClients := make([]*models.Client, 0, ClientsLimitMax+2)
+	for i := 0; i < ClientsLimitMax+2; i++ {
+		allClients = append(allClients, &models.Client{ID: strconv.Itoa(i)})
+	}
+
+	requestedOffsets := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "linux", r.URL.Query().Get("filter[os_kernel]"))
+		assert.Equal(t, strconv.Itoa(ClientsLimitMax), r.URL.Query().Get("page[limit]"))
+
+		offsetStr := r.URL.Query().Get("page[offset]")
+		requestedOffsets = append(requestedOffsets, offsetStr)
+		offset, err := strconv.Atoi(offsetStr)
+		require.NoError(t, err)
+
+		end := offset + ClientsLimitMax
+		if end > len(allClients) {
+			end = len(allClients)
+		}
+		e := json.NewEncoder(rw).Encode(ClientsResponse{
+			Data: allClients[offset:end],
+			Meta: ClientsMeta{Count: len(allClients)},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	clients, err := cl.AllClients(context.Background(), NewFilters("os_kernel", "linux"))
+	require.NoError(t, err)
+
+	assert.Equal(t, allClients, clients)
+	assert.Equal(t, []string{"0", strconv.Itoa(ClientsLimitMax)}, requestedOffsets)
+}
+
+func TestClientsPageIteratorStopsOnEmptyPage(t *testing.T) {
+	requestsCount := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requestsCount++
+		data := []*models.Client{}
+		if r.URL.Query().Get("page[offset]") == "0" {
+			data = clientsStub
+		}
+		// no meta count, like older servers do
+		e := json.NewEncoder(rw).Encode(ClientsResponse{Data: data})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	it := New(srv.URL, nil).NewClientsPageIterator(Filters{}, len(clientsStub))
+
+	page, hasPage, err := it.Next(context.Background())
+	require.NoError(t, err)
+	assert.True(t, hasPage)
+	assert.Equal(t, clientsStub, page)
+
+	_, hasPage, err = it.Next(context.Background())
+	require.NoError(t, err)
+	assert.False(t, hasPage)
+	assert.False(t, it.Truncated())
+	assert.Equal(t, 2, requestsCount)
+}
+
+func TestClientsResponseTruncated(t *testing.T) {
+	cr := &ClientsResponse{Data: clientsStub, Meta: ClientsMeta{Count: 10}}
+
+	assert.True(t, cr.Truncated(Pagination{Limit: 2, Offset: 0}))
+	assert.False(t, cr.Truncated(Pagination{Limit: 2, Offset: 8}))
+}
+
+func TestDeleteClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v1/clients/123", r.URL.String())
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	err := cl.DeleteClient(context.Background(), "123")
+	require.NoError(t, err)
+}
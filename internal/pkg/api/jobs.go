@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	url2 "net/url"
+	"time"
+
+	"github.com/breathbath/go_utils/v2/pkg/url"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	ClientJobsURL = "/api/v1/clients/%s/commands"
+	ClientJobURL  = "/api/v1/clients/%s/commands/%s"
+	MultiJobsURL  = "/api/v1/commands"
+	MultiJobURL   = "/api/v1/commands/%s"
+	ScriptsURL    = "/api/v1/scripts"
+)
+
+// JobsFilter narrows down the job history, empty values and zero times are not sent to the server
+type JobsFilter struct {
+	Status    string
+	CreatedBy string
+	Since     time.Time
+	Until     time.Time
+}
+
+func (jf JobsFilter) Apply(q url2.Values) {
+	NewFilters("status", jf.Status, "created_by", jf.CreatedBy).Apply(q)
+	if !jf.Since.IsZero() {
+		q.Set("filter[started_at][gt]", jf.Since.UTC().Format(time.RFC3339))
+	}
+	if !jf.Until.IsZero() {
+		q.Set("filter[started_at][lt]", jf.Until.UTC().Format(time.RFC3339))
+	}
+}
+
+type JobsResponse struct {
+	Data []*models.Job
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more jobs matching the filter than returned for the given pagination
+func (jr *JobsResponse) Truncated(pagination Pagination) bool {
+	return jr.Meta.Count > pagination.Offset+len(jr.Data)
+}
+
+type JobResponse struct {
+	Data *models.Job
+}
+
+type MultiJobsResponse struct {
+	Data []*models.MultiJob
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more multi-client jobs matching the filter than returned for the given pagination
+func (mjr *MultiJobsResponse) Truncated(pagination Pagination) bool {
+	return mjr.Meta.Count > pagination.Offset+len(mjr.Data)
+}
+
+type MultiJobResponse struct {
+	Data *models.MultiJob
+}
+
+// ClientJobs fetches the command history of a single client, newest jobs first
+func (rp *Rport) ClientJobs(ctx context.Context, clientID string, pagination Pagination, filter JobsFilter) (*JobsResponse, error) {
+	jr := &JobsResponse{}
+	err := rp.getJobs(ctx, fmt.Sprintf(ClientJobsURL, url2.PathEscape(clientID)), pagination, filter, jr)
+	if err != nil {
+		return nil, err
+	}
+
+	return jr, nil
+}
+
+// ClientJob fetches a single job of a client including its output
+func (rp *Rport) ClientJob(ctx context.Context, clientID, jid string) (*models.Job, error) {
+	jr := &JobResponse{}
+	err := rp.getResource(ctx, fmt.Sprintf(ClientJobURL, url2.PathEscape(clientID), url2.PathEscape(jid)), jr)
+	if err != nil {
+		return nil, err
+	}
+
+	return jr.Data, nil
+}
+
+// MultiJobs fetches the history of commands and scripts executed on several clients at once, newest jobs first
+func (rp *Rport) MultiJobs(ctx context.Context, pagination Pagination, filter JobsFilter) (*MultiJobsResponse, error) {
+	mjr := &MultiJobsResponse{}
+	err := rp.getJobs(ctx, MultiJobsURL, pagination, filter, mjr)
+	if err != nil {
+		return nil, err
+	}
+
+	return mjr, nil
+}
+
+// MultiJob fetches a multi-client job together with the jobs of every client
+func (rp *Rport) MultiJob(ctx context.Context, jid string) (*models.MultiJob, error) {
+	mjr := &MultiJobResponse{}
+	err := rp.getResource(ctx, fmt.Sprintf(MultiJobURL, url2.PathEscape(jid)), mjr)
+	if err != nil {
+		return nil, err
+	}
+
+	return mjr.Data, nil
+}
+
+type JobStartedResponse struct {
+	Data *models.JobStarted
+}
+
+// StartMultiJob starts a command or, if the script is set, a script on the clients without waiting for the results
+func (rp *Rport) StartMultiJob(ctx context.Context, wsCmd *models.WsScriptCommand) (*models.JobStarted, error) {
+	path := MultiJobsURL
+	if wsCmd.Script != "" {
+		path = ScriptsURL
+	}
+
+	buf := &bytes.Buffer{}
+	err := json.NewEncoder(buf).Encode(wsCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.JoinURL(rp.BaseURL, path), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	jsr := &JobStartedResponse{}
+	_, err = rp.CallBaseClient(req, jsr)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsr.Data, nil
+}
+
+func (rp *Rport) getJobs(ctx context.Context, path string, pagination Pagination, filter JobsFilter, target interface{}) error {
+	u, err := url2.Parse(url.JoinURL(rp.BaseURL, path))
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	pagination.Apply(q)
+	filter.Apply(q)
+	q.Set("sort", "-started_at")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = rp.CallBaseClient(req, target)
+
+	return err
+}
+
+func (rp *Rport) getResource(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.JoinURL(rp.BaseURL, path), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = rp.CallBaseClient(req, target)
+
+	return err
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestClientJobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/clients/123/commands", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "10", q.Get("page[limit]"))
+		assert.Equal(t, "failed", q.Get("filter[status]"))
+		assert.Equal(t, "", q.Get("filter[created_by]"))
+		assert.Equal(t, "2022-03-01T10:00:00Z", q.Get("filter[started_at][gt]"))
+		assert.Equal(t, "2022-03-01T11:00:00Z", q.Get("filter[started_at][lt]"))
+		assert.Equal(t, "-started_at", q.Get("sort"))
+		e := json.NewEncoder(rw).Encode(JobsResponse{
+			Data: []*models.Job{{Jid: "j1", Status: "failed"}},
+			Meta: ClientsMeta{Count: 11},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	pagination := Pagination{Limit: 10}
+	resp, err := cl.ClientJobs(context.Background(), "123", pagination, JobsFilter{
+		Status: "failed",
+		Since:  time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC),
+		Until:  time.Date(2022, 3, 1, 11, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.Job{{Jid: "j1", Status: "failed"}}, resp.Data)
+	assert.True(t, resp.Truncated(pagination))
+}
+
+func TestMultiJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/commands/j1", r.URL.Path)
+		e := json.NewEncoder(rw).Encode(MultiJobResponse{
+			Data: &models.MultiJob{Jid: "j1", Jobs: []*models.Job{{Jid: "c1", MultiJobID: "j1"}}},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	mj, err := cl.MultiJob(context.Background(), "j1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "j1", mj.Jid)
+	assert.Equal(t, []*models.Job{{Jid: "c1", MultiJobID: "j1"}}, mj.Jobs)
+}
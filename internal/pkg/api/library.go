@@ -0,0 +1,243 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	url2 "net/url"
+
+	"github.com/breathbath/go_utils/v2/pkg/url"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	StoredCommandsURL = "/api/v1/library/commands"
+	StoredCommandURL  = "/api/v1/library/commands/%s"
+	StoredScriptsURL  = "/api/v1/library/scripts"
+	StoredScriptURL   = "/api/v1/library/scripts/%s"
+)
+
+type StoredCommandsResponse struct {
+	Data []*models.StoredCommand
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more stored commands matching the filters than returned for the given pagination
+func (scr *StoredCommandsResponse) Truncated(pagination Pagination) bool {
+	return scr.Meta.Count > pagination.Offset+len(scr.Data)
+}
+
+type StoredCommandResponse struct {
+	Data *models.StoredCommand
+}
+
+// storedCommandBody holds the fields of a stored command which can be changed, the others are managed by the server
+type storedCommandBody struct {
+	Name        string `json:"name"`
+	Cmd         string `json:"cmd"`
+	Interpreter string `json:"interpreter,omitempty"`
+}
+
+// StoredCommands fetches a page of the command library sorted by name
+func (rp *Rport) StoredCommands(ctx context.Context, pagination Pagination, filters Filters) (*StoredCommandsResponse, error) {
+	scr := &StoredCommandsResponse{}
+	err := rp.getNamedItems(ctx, StoredCommandsURL, pagination, filters, scr)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr, nil
+}
+
+func (rp *Rport) StoredCommand(ctx context.Context, id string) (*models.StoredCommand, error) {
+	scr := &StoredCommandResponse{}
+	err := rp.getResource(ctx, fmt.Sprintf(StoredCommandURL, url2.PathEscape(id)), scr)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Data, nil
+}
+
+func (rp *Rport) CreateStoredCommand(ctx context.Context, sc *models.StoredCommand) (*models.StoredCommand, error) {
+	scr := &StoredCommandResponse{}
+	err := rp.sendItem(ctx, http.MethodPost, StoredCommandsURL, newStoredCommandBody(sc), scr)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Data, nil
+}
+
+// UpdateStoredCommand replaces the name, command and interpreter of the stored command with the given id
+func (rp *Rport) UpdateStoredCommand(ctx context.Context, id string, sc *models.StoredCommand) (*models.StoredCommand, error) {
+	scr := &StoredCommandResponse{}
+	err := rp.sendItem(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf(StoredCommandURL, url2.PathEscape(id)),
+		newStoredCommandBody(sc),
+		scr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Data, nil
+}
+
+func (rp *Rport) DeleteStoredCommand(ctx context.Context, id string) error {
+	return rp.deleteItem(ctx, fmt.Sprintf(StoredCommandURL, url2.PathEscape(id)))
+}
+
+func newStoredCommandBody(sc *models.StoredCommand) *storedCommandBody {
+	return &storedCommandBody{
+		Name:        sc.Name,
+		Cmd:         sc.Cmd,
+		Interpreter: sc.Interpreter,
+	}
+}
+
+type StoredScriptsResponse struct {
+	Data []*models.StoredScript
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more stored scripts matching the filters than returned for the given pagination
+func (ssr *StoredScriptsResponse) Truncated(pagination Pagination) bool {
+	return ssr.Meta.Count > pagination.Offset+len(ssr.Data)
+}
+
+type StoredScriptResponse struct {
+	Data *models.StoredScript
+}
+
+// storedScriptBody holds the fields of a stored script which can be changed, the others are managed by the server
+type storedScriptBody struct {
+	Name        string `json:"name"`
+	Script      string `json:"script"`
+	Interpreter string `json:"interpreter"`
+	Cwd         string `json:"cwd"`
+	IsSudo      bool   `json:"is_sudo"`
+}
+
+// StoredScripts fetches a page of the script library sorted by name
+func (rp *Rport) StoredScripts(ctx context.Context, pagination Pagination, filters Filters) (*StoredScriptsResponse, error) {
+	ssr := &StoredScriptsResponse{}
+	err := rp.getNamedItems(ctx, StoredScriptsURL, pagination, filters, ssr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssr, nil
+}
+
+func (rp *Rport) StoredScript(ctx context.Context, id string) (*models.StoredScript, error) {
+	ssr := &StoredScriptResponse{}
+	err := rp.getResource(ctx, fmt.Sprintf(StoredScriptURL, url2.PathEscape(id)), ssr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssr.Data, nil
+}
+
+func (rp *Rport) CreateStoredScript(ctx context.Context, ss *models.StoredScript) (*models.StoredScript, error) {
+	ssr := &StoredScriptResponse{}
+	err := rp.sendItem(ctx, http.MethodPost, StoredScriptsURL, newStoredScriptBody(ss), ssr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssr.Data, nil
+}
+
+// UpdateStoredScript replaces the script and its properties of the stored script with the given id
+func (rp *Rport) UpdateStoredScript(ctx context.Context, id string, ss *models.StoredScript) (*models.StoredScript, error) {
+	ssr := &StoredScriptResponse{}
+	err := rp.sendItem(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf(StoredScriptURL, url2.PathEscape(id)),
+		newStoredScriptBody(ss),
+		ssr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssr.Data, nil
+}
+
+func (rp *Rport) DeleteStoredScript(ctx context.Context, id string) error {
+	return rp.deleteItem(ctx, fmt.Sprintf(StoredScriptURL, url2.PathEscape(id)))
+}
+
+func newStoredScriptBody(ss *models.StoredScript) *storedScriptBody {
+	return &storedScriptBody{
+		Name:        ss.Name,
+		Script:      ss.Script,
+		Interpreter: ss.Interpreter,
+		Cwd:         ss.Cwd,
+		IsSudo:      ss.IsSudo,
+	}
+}
+
+func (rp *Rport) getNamedItems(ctx context.Context, path string, pagination Pagination, filters Filters, target interface{}) error {
+	u, err := url2.Parse(url.JoinURL(rp.BaseURL, path))
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	pagination.Apply(q)
+	filters.Apply(q)
+	q.Set("sort", "name")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = rp.CallBaseClient(req, target)
+
+	return err
+}
+
+func (rp *Rport) sendItem(ctx context.Context, method, path string, body, target interface{}) error {
+	buf := &bytes.Buffer{}
+	err := json.NewEncoder(buf).Encode(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.JoinURL(rp.BaseURL, path), buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = rp.CallBaseClient(req, target)
+
+	return err
+}
+
+func (rp *Rport) deleteItem(ctx context.Context, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.JoinURL(rp.BaseURL, path), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rp.CallBaseClient(req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected response code %d, %d is expected", resp.StatusCode, http.StatusNoContent)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestStoredCommands(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, StoredCommandsURL, r.URL.Path)
+		assert.Equal(t, "uptime", r.URL.Query().Get("filter[name]"))
+		assert.Equal(t, "name", r.URL.Query().Get("sort"))
+		e := json.NewEncoder(rw).Encode(StoredCommandsResponse{
+			Data: []*models.StoredCommand{{ID: "sc1", Name: "uptime", Cmd: "uptime"}},
+			Meta: ClientsMeta{Count: 1},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	pagination := NewPaginationWithLimit(10)
+	resp, err := cl.StoredCommands(context.Background(), pagination, NewFilters("name", "uptime"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.StoredCommand{{ID: "sc1", Name: "uptime", Cmd: "uptime"}}, resp.Data)
+	assert.False(t, resp.Truncated(pagination))
+}
+
+func TestCreateStoredCommand(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, StoredCommandsURL, r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"uptime","cmd":"uptime"}`, string(body))
+		rw.WriteHeader(http.StatusCreated)
+		e := json.NewEncoder(rw).Encode(StoredCommandResponse{
+			Data: &models.StoredCommand{ID: "sc1", Name: "uptime", Cmd: "uptime", CreatedBy: "admin"},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	sc, err := cl.CreateStoredCommand(context.Background(), &models.StoredCommand{ID: "ignored", Name: "uptime", Cmd: "uptime"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "sc1", sc.ID)
+	assert.Equal(t, "admin", sc.CreatedBy)
+}
+
+func TestDeleteStoredCommand(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, StoredCommandsURL+"/sc1", r.URL.Path)
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	err := cl.DeleteStoredCommand(context.Background(), "sc1")
+	require.NoError(t, err)
+}
+
+func TestUpdateStoredScript(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, StoredScriptsURL+"/ss1", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"cleanup","script":"rm -rf /tmp/cache","interpreter":"bash","cwd":"/tmp","is_sudo":true}`, string(body))
+		e := json.NewEncoder(rw).Encode(StoredScriptResponse{
+			Data: &models.StoredScript{ID: "ss1", Name: "cleanup", Script: "rm -rf /tmp/cache", UpdatedBy: "admin"},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	ss, err := cl.UpdateStoredScript(context.Background(), "ss1", &models.StoredScript{
+		ID:          "ss1",
+		Name:        "cleanup",
+		Script:      "rm -rf /tmp/cache",
+		Interpreter: "bash",
+		Cwd:         "/tmp",
+		IsSudo:      true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "admin", ss.UpdatedBy)
+}
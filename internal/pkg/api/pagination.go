@@ -10,6 +10,7 @@ import (
 const (
 	PaginationOffset = "offset"
 	PaginationLimit  = "limit"
+	PaginationAll    = "all-pages"
 )
 
 type Pagination struct {
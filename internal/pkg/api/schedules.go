@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	url2 "net/url"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	SchedulesURL = "/api/v1/schedules"
+	ScheduleURL  = "/api/v1/schedules/%s"
+)
+
+type SchedulesResponse struct {
+	Data []*models.Schedule
+	Meta ClientsMeta
+}
+
+// Truncated tells if the server has more schedules matching the filters than returned for the given pagination
+func (sr *SchedulesResponse) Truncated(pagination Pagination) bool {
+	return sr.Meta.Count > pagination.Offset+len(sr.Data)
+}
+
+type ScheduleResponse struct {
+	Data *models.Schedule
+}
+
+// scheduleBody holds the fields of a schedule which can be changed, the others are managed by the server
+type scheduleBody struct {
+	Name                string   `json:"name"`
+	Schedule            string   `json:"schedule"`
+	Type                string   `json:"type"`
+	ClientIDs           []string `json:"client_ids"`
+	GroupIDs            []string `json:"group_ids,omitempty"`
+	Command             string   `json:"command"`
+	Interpreter         string   `json:"interpreter"`
+	Cwd                 string   `json:"cwd"`
+	IsSudo              bool     `json:"is_sudo"`
+	TimeoutSec          int      `json:"timeout_sec"`
+	ExecuteConcurrently bool     `json:"execute_concurrently"`
+	AbortOnError        bool     `json:"abort_on_error"`
+}
+
+// Schedules fetches a page of the scheduled jobs sorted by name
+func (rp *Rport) Schedules(ctx context.Context, pagination Pagination, filters Filters) (*SchedulesResponse, error) {
+	sr := &SchedulesResponse{}
+	err := rp.getNamedItems(ctx, SchedulesURL, pagination, filters, sr)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr, nil
+}
+
+func (rp *Rport) Schedule(ctx context.Context, id string) (*models.Schedule, error) {
+	sr := &ScheduleResponse{}
+	err := rp.getResource(ctx, fmt.Sprintf(ScheduleURL, url2.PathEscape(id)), sr)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.Data, nil
+}
+
+func (rp *Rport) CreateSchedule(ctx context.Context, s *models.Schedule) (*models.Schedule, error) {
+	sr := &ScheduleResponse{}
+	err := rp.sendItem(ctx, http.MethodPost, SchedulesURL, newScheduleBody(s), sr)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.Data, nil
+}
+
+// UpdateSchedule replaces the cron expression, targets and command of the schedule with the given id
+func (rp *Rport) UpdateSchedule(ctx context.Context, id string, s *models.Schedule) (*models.Schedule, error) {
+	sr := &ScheduleResponse{}
+	err := rp.sendItem(ctx, http.MethodPut, fmt.Sprintf(ScheduleURL, url2.PathEscape(id)), newScheduleBody(s), sr)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.Data, nil
+}
+
+func (rp *Rport) DeleteSchedule(ctx context.Context, id string) error {
+	return rp.deleteItem(ctx, fmt.Sprintf(ScheduleURL, url2.PathEscape(id)))
+}
+
+func newScheduleBody(s *models.Schedule) *scheduleBody {
+	return &scheduleBody{
+		Name:                s.Name,
+		Schedule:            s.Schedule,
+		Type:                s.Type,
+		ClientIDs:           s.ClientIDs,
+		GroupIDs:            s.GroupIDs,
+		Command:             s.Command,
+		Interpreter:         s.Interpreter,
+		Cwd:                 s.Cwd,
+		IsSudo:              s.IsSudo,
+		TimeoutSec:          s.TimeoutSec,
+		ExecuteConcurrently: s.ExecuteConcurrently,
+		AbortOnError:        s.AbortOnError,
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestSchedules(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, SchedulesURL, r.URL.Path)
+		assert.Equal(t, "nightly-cleanup", r.URL.Query().Get("filter[name]"))
+		assert.Equal(t, "name", r.URL.Query().Get("sort"))
+		e := json.NewEncoder(rw).Encode(SchedulesResponse{
+			Data: []*models.Schedule{{ID: "s1", Name: "nightly-cleanup", Schedule: "0 3 * * *"}},
+			Meta: ClientsMeta{Count: 3},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	pagination := NewPaginationWithLimit(1)
+	resp, err := cl.Schedules(context.Background(), pagination, NewFilters("name", "nightly-cleanup"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.Schedule{{ID: "s1", Name: "nightly-cleanup", Schedule: "0 3 * * *"}}, resp.Data)
+	assert.True(t, resp.Truncated(pagination))
+}
+
+func TestCreateSchedule(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, SchedulesURL, r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(
+			t,
+			`{"name":"uptime","schedule":"@hourly","type":"command","client_ids":null,"group_ids":["g1"],"command":"uptime","interpreter":"","cwd":"","is_sudo":false,"timeout_sec":30,"execute_concurrently":false,"abort_on_error":false}`,
+			string(body),
+		)
+		rw.WriteHeader(http.StatusCreated)
+		e := json.NewEncoder(rw).Encode(ScheduleResponse{
+			Data: &models.Schedule{ID: "s1", Name: "uptime", CreatedBy: "admin"},
+		})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	cl := New(srv.URL, nil)
+	s, err := cl.CreateSchedule(context.Background(), &models.Schedule{
+		ID:         "ignored",
+		Name:       "uptime",
+		Schedule:   "@hourly",
+		Type:       models.ScheduleTypeCommand,
+		GroupIDs:   []string{"g1"},
+		Command:    "uptime",
+		TimeoutSec: 30,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "s1", s.ID)
+	assert.Equal(t, "admin", s.CreatedBy)
+}
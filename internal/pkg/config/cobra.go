@@ -34,6 +34,8 @@ func DefineCommandInputs(c *cobra.Command, reqs []ParameterRequirement) {
 			}
 		case StringSliceRequirementType:
 			c.Flags().StringSliceP(req.Field, req.ShortName, nil, req.Description)
+		case StringArrayRequirementType:
+			c.Flags().StringArrayP(req.Field, req.ShortName, nil, req.Description)
 		default:
 			c.Flags().StringP(req.Field, req.ShortName, defaultStr, req.Description)
 		}
@@ -48,6 +50,10 @@ func CreateFlagValuesProvider(flags *pflag.FlagSet) options.ValuesProvider {
 	return &FlagValuesProvider{flags: flags}
 }
 
+func CreateUsedFlagsChecker(flags *pflag.FlagSet) UsedFlagsChecker {
+	return &FlagValuesProvider{flags: flags}
+}
+
 func (fvp *FlagValuesProvider) Dump(w io.Writer) (err error) {
 	jsonEncoder := json.NewEncoder(w)
 	err = jsonEncoder.Encode(fvp.ToKeyValues())
@@ -93,6 +99,12 @@ func (fvp *FlagValuesProvider) ReadFlag(reqField, reqType string) (result interf
 			return nil, false, e
 		}
 		return sliceVal, true, nil
+	case StringArrayRequirementType:
+		arrayVal, e := flags.GetStringArray(reqField)
+		if e != nil {
+			return nil, false, e
+		}
+		return arrayVal, true, nil
 	default:
 		strVal, e := flags.GetString(reqField)
 		if e != nil {
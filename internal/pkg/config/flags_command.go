@@ -2,6 +2,8 @@ package config
 
 import (
 	"strconv"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
 )
 
 const (
@@ -15,6 +17,20 @@ func GetCommandParamReqs() (paramReqs []ParameterRequirement) {
 		GetReadYAMLParamReq(),
 		GetWriteExecutionLogParamReq(),
 		GetReadExecutionLogParamReq(),
+		GetDetachParamReq(),
+		GetAggregateParamReq(),
+		GetOutputDirParamReq(),
+		GetFailThresholdParamReq(),
+		GetReportParamReq(),
+		GetRetriesParamReq(),
+		GetRetryDelayParamReq(),
+		GetRetryBackoffParamReq(),
+		GetBatchSizeParamReq(),
+		GetCanaryParamReq(),
+		GetPauseBetweenParamReq(),
+		GetMaxFailureRateParamReq(),
+		GetTemplateParamReq(),
+		GetDryRunParamReq(),
 		GetClientIDsParamReq(commandClientIDsDescription),
 		{
 			Field:       ClientNameFlag,
@@ -29,9 +45,16 @@ func GetCommandParamReqs() (paramReqs []ParameterRequirement) {
 		{
 			Field:       Command,
 			Help:        "Enter command",
-			Description: "[required] Command which should be executed on the clients",
+			Description: "[required] Command which should be executed on the clients, unless --from-library is given",
 			ShortName:   "c",
 			IsRequired:  true,
+			IsEnabled: func(providedParams *options.ParameterBag) bool {
+				return providedParams.ReadString(FromLibrary, "") == ""
+			},
+		},
+		{
+			Field:       FromLibrary,
+			Description: "Name or id of a command from the command library to execute instead of --command",
 		},
 		{
 			Field:       Timeout,
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+const (
+	Cron          = "cron"
+	NextFireTimes = "next"
+
+	DefaultNextFireTimes = 5
+)
+
+// scheduleExcludedFields are the command execution parameters which don't apply to a scheduled command
+var scheduleExcludedFields = map[string]bool{
+	WriteExecLog:   true,
+	ReadExecLog:    true,
+	Detach:         true,
+	IsFullOutput:   true,
+	Aggregate:      true,
+	OutputDir:      true,
+	FailThreshold:  true,
+	Report:         true,
+	Retries:        true,
+	RetryDelay:     true,
+	RetryBackoff:   true,
+	BatchSize:      true,
+	Canary:         true,
+	PauseBetween:   true,
+	MaxFailureRate: true,
+	Template:       true,
+	DryRun:         true,
+}
+
+// GetScheduleParamReqs reuses the targeting and execution parameters of command execute
+// and adds the cron expression telling when the command is executed
+func GetScheduleParamReqs() (paramReqs []ParameterRequirement) {
+	for _, req := range GetCommandParamReqs() {
+		if !scheduleExcludedFields[req.Field] {
+			paramReqs = append(paramReqs, req)
+		}
+	}
+
+	return append(
+		paramReqs,
+		ParameterRequirement{
+			Field:       Cron,
+			Help:        "Enter cron expression, e.g. 0 3 * * *",
+			Description: "[required] Cron expression telling when the command is executed, e.g. \"0 3 * * *\" or @daily",
+			Validate:    CronValidate,
+			IsRequired:  true,
+		},
+		getNextFireTimesParamReq(),
+	)
+}
+
+// GetScheduleUpdateParamReqs lists the values of a schedule which can be changed, none of them is required
+func GetScheduleUpdateParamReqs() []ParameterRequirement {
+	return []ParameterRequirement{
+		GetNoPromptParamReq(),
+		{
+			Field:       NewName,
+			Description: "New name of the schedule",
+		},
+		{
+			Field:       Cron,
+			Description: "New cron expression",
+		},
+		{
+			Field:       Command,
+			Description: "New command",
+			ShortName:   "c",
+		},
+		{
+			Field:       Interpreter,
+			Description: "New interpreter/shell name",
+			ShortName:   "i",
+		},
+		{
+			Field:       Cwd,
+			Description: "New working directory",
+			ShortName:   "w",
+		},
+		{
+			Field:       Timeout,
+			Description: "New timeout in seconds",
+			ShortName:   "t",
+			Default:     strconv.Itoa(DefaultCmdTimeoutSeconds),
+		},
+		{
+			Field:       IsSudo,
+			Description: "execute the command as sudo",
+			ShortName:   "u",
+			Type:        BoolRequirementType,
+			Default:     false,
+		},
+		{
+			Field:       ExecConcurrently,
+			Description: "execute the command concurrently on multiple clients",
+			ShortName:   "r",
+			Type:        BoolRequirementType,
+			Default:     false,
+		},
+		{
+			Field:       AbortOnError,
+			Description: "if true and the command fails on one client, it's not executed on others",
+			ShortName:   "a",
+			Type:        BoolRequirementType,
+			Default:     false,
+		},
+		{
+			Field:       ClientIDs,
+			Description: "Comma separated client ids replacing the targets of the schedule",
+			ShortName:   "d",
+		},
+		{
+			Field:       ClientNamesFlag,
+			Description: "Comma separated client names replacing the targets of the schedule",
+			ShortName:   "n",
+		},
+		{
+			Field:       GroupIDs,
+			Description: "Comma separated client group ids replacing the targets of the schedule",
+			ShortName:   "g",
+		},
+		{
+			Field:       ClientCombinedSearchFlag,
+			Description: "search by key value",
+		},
+		getNextFireTimesParamReq(),
+	}
+}
+
+func getNextFireTimesParamReq() ParameterRequirement {
+	return ParameterRequirement{
+		Field:       NextFireTimes,
+		Description: "Number of upcoming fire times of the cron expression to show before the schedule is submitted",
+		Type:        IntRequirementType,
+		Default:     DefaultNextFireTimes,
+	}
+}
+
+// CronValidate validates the cron expression already while prompting for it
+var CronValidate = func(fieldName string, val interface{}) error {
+	err := RequiredValidate(fieldName, val)
+	if err != nil {
+		return err
+	}
+
+	_, err = utils.ParseCron(fmt.Sprint(val))
+	return err
+}
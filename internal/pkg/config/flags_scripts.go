@@ -17,6 +17,20 @@ func GetScriptParamReqs() (paramReqs []ParameterRequirement) {
 		GetReadYAMLParamReq(),
 		GetWriteExecutionLogParamReq(),
 		GetReadExecutionLogParamReq(),
+		GetDetachParamReq(),
+		GetAggregateParamReq(),
+		GetOutputDirParamReq(),
+		GetFailThresholdParamReq(),
+		GetReportParamReq(),
+		GetRetriesParamReq(),
+		GetRetryDelayParamReq(),
+		GetRetryBackoffParamReq(),
+		GetBatchSizeParamReq(),
+		GetCanaryParamReq(),
+		GetPauseBetweenParamReq(),
+		GetMaxFailureRateParamReq(),
+		GetTemplateParamReq(),
+		GetDryRunParamReq(),
 		GetClientIDsParamReq(scriptsClientIDsDescription),
 		{
 			Field:       ClientNameFlag,
@@ -36,7 +50,8 @@ func GetScriptParamReqs() (paramReqs []ParameterRequirement) {
 			ShortName:   "s",
 			IsRequired:  true,
 			IsEnabled: func(providedParams *options.ParameterBag) bool {
-				return providedParams.ReadString(EmbeddedScript, "") == ""
+				return providedParams.ReadString(EmbeddedScript, "") == "" &&
+					providedParams.ReadString(FromLibrary, "") == ""
 			},
 		},
 		{
@@ -47,9 +62,15 @@ func GetScriptParamReqs() (paramReqs []ParameterRequirement) {
 			ShortName:   "c",
 			IsRequired:  true,
 			IsEnabled: func(providedParams *options.ParameterBag) bool {
-				return providedParams.ReadString(Script, "") == ""
+				return providedParams.ReadString(Script, "") == "" &&
+					providedParams.ReadString(FromLibrary, "") == ""
 			},
 		},
+		{
+			Field: FromLibrary,
+			Description: "Name or id of a script from the script library to execute instead of --script or --exec. " +
+				"Interpreter, cwd and sudo are taken from the stored script unless given on the command line or in a yaml file",
+		},
 		{
 			Field:       Timeout,
 			Help:        "Enter timeout in seconds",
@@ -102,5 +123,17 @@ func GetScriptParamReqs() (paramReqs []ParameterRequirement) {
 			Type:        StringRequirementType,
 			Default:     "",
 		},
+		{
+			Field: ScriptArg,
+			Description: "positional argument passed to the script, can be used multiple times, " +
+				"requires the interpreter to be sh, bash, zsh, powershell, pwsh or cmd",
+			Type: StringArrayRequirementType,
+		},
+		{
+			Field: ScriptEnv,
+			Description: "environment variable as KEY=VALUE exported to the script, can be used multiple times, " +
+				"requires the interpreter to be sh, bash, zsh, powershell, pwsh or cmd",
+			Type: StringArrayRequirementType,
+		},
 	}
 }
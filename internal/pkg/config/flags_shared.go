@@ -12,6 +12,8 @@ const (
 	Command          = "command"
 	Script           = "script"
 	EmbeddedScript   = "exec"
+	ScriptArg        = "arg"
+	ScriptEnv        = "env"
 	GroupIDs         = "gids"
 	Timeout          = "timeout"
 	ExecConcurrently = "conc"
@@ -22,6 +24,20 @@ const (
 	IsFullOutput     = "full-command-response"
 	WriteExecLog     = "write-execlog"
 	ReadExecLog      = "read-execlog"
+	Detach           = "detach"
+	Aggregate        = "aggregate"
+	OutputDir        = "output-dir"
+	FailThreshold    = "fail-threshold"
+	Report           = "report"
+	Retries          = "retries"
+	RetryDelay       = "retry-delay"
+	RetryBackoff     = "retry-backoff"
+	BatchSize        = "batch-size"
+	Canary           = "canary"
+	PauseBetween     = "pause-between"
+	MaxFailureRate   = "max-failure-rate"
+	Template         = "template"
+	DryRun           = "dry-run"
 
 	ClientID           = "client"
 	TunnelID           = "tunnel"
@@ -42,7 +58,32 @@ const (
 	ForceDeletion      = "force"
 	UseHTTPProxy       = "http-proxy"
 
+	Description = "description"
+
+	ClientFields  = "fields"
+	ClientSort    = "sort"
+	ClientStatsBy = "by"
+
+	WatchInterval = "interval"
+
+	UpdatesAvailable         = "updates-available"
+	SecurityUpdatesAvailable = "security-updates-available"
+	RebootPending            = "reboot-pending"
+
+	DisconnectedFor = "disconnected-for"
+
+	Since = "since"
+	Until = "until"
+
+	JobStatus = "status"
+	CreatedBy = "created-by"
+
+	FromLibrary = "from-library"
+	NewName     = "new-name"
+
 	DefaultCmdTimeoutSeconds = 30
+	DefaultRetryDelay        = "10s"
+	DefaultRetryBackoff      = "1"
 )
 
 func GetNoPromptParamReq() (paramReq ParameterRequirement) {
@@ -94,6 +135,135 @@ func GetWriteExecutionLogParamReq() (paramReq ParameterRequirement) {
 	}
 }
 
+func GetDetachParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: Detach,
+		Description: "start the execution in the background, print the multi job id and exit without waiting for the results, " +
+			"use 'job wait' to get them later",
+		Type:    BoolRequirementType,
+		Default: false,
+	}
+}
+
+func GetAggregateParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: Aggregate,
+		Description: "wait for all clients to finish and show each distinct output once with the names of the clients " +
+			"which produced it, the biggest groups first",
+		Type:    BoolRequirementType,
+		Default: false,
+	}
+}
+
+func GetOutputDirParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: OutputDir,
+		Description: "write the stdout, stderr and meta data of every client to <client>.stdout, <client>.stderr and " +
+			"<client>.meta.json in the directory together with an index.json of the run",
+		Type: StringRequirementType,
+	}
+}
+
+func GetFailThresholdParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: FailThreshold,
+		Description: "number like 3 or percentage like 10% of failed jobs which is tolerated before exiting " +
+			"with a non-zero exit code, by default any failed job is an error",
+		Type: StringRequirementType,
+	}
+}
+
+func GetReportParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: Report,
+		Description: "write the results as a test report for CI systems, junit=FILE for JUnit XML or tap=FILE for TAP, " +
+			"can be used multiple times",
+		Type: StringSliceRequirementType,
+	}
+}
+
+func GetRetriesParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field:       Retries,
+		Description: "number of times the command is executed again on the clients it has failed on",
+		Type:        IntRequirementType,
+		Default:     "0",
+	}
+}
+
+func GetRetryDelayParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field:       RetryDelay,
+		Description: "time to wait before the first retry, e.g. 30s or 2m",
+		Type:        StringRequirementType,
+		Default:     DefaultRetryDelay,
+	}
+}
+
+func GetRetryBackoffParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field:       RetryBackoff,
+		Description: "factor the retry delay is multiplied with after each retry, e.g. 2 doubles the delay",
+		Type:        StringRequirementType,
+		Default:     DefaultRetryBackoff,
+	}
+}
+
+func GetBatchSizeParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: BatchSize,
+		Description: "number like 10 or percentage like 10% of the clients to execute on at once, " +
+			"the next batch starts when all jobs of a batch have finished",
+		Type: StringRequirementType,
+	}
+}
+
+func GetCanaryParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field:       Canary,
+		Description: "number of clients to execute on first, the execution stops if it fails on any of them",
+		Type:        IntRequirementType,
+		Default:     "0",
+	}
+}
+
+func GetPauseBetweenParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field:       PauseBetween,
+		Description: "time to wait between batches, e.g. 60s or 5m",
+		Type:        StringRequirementType,
+		Default:     "0s",
+	}
+}
+
+func GetMaxFailureRateParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: MaxFailureRate,
+		Description: "number like 3 or percentage like 5% of failed jobs after which no further batch is started, " +
+			"by default all batches are executed",
+		Type: StringRequirementType,
+	}
+}
+
+func GetTemplateParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field: Template,
+		Description: "render the command or script as Go text/template for each client, e.g. {{.Name}} or " +
+			"{{index .Ipv4 0}}, clients with the same result get the same command",
+		Type:    BoolRequirementType,
+		Default: false,
+	}
+}
+
+func GetDryRunParamReq() (paramReq ParameterRequirement) {
+	return ParameterRequirement{
+		Field:       DryRun,
+		Description: "show the targeted clients and the command or script each of them would execute without executing it",
+		Type:        BoolRequirementType,
+		Default:     false,
+	}
+}
+
 func GetReadExecutionLogParamReq() (paramReq ParameterRequirement) {
 	return ParameterRequirement{
 		Field: ReadExecLog,
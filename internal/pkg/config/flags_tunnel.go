@@ -141,6 +141,7 @@ func isRemoteEnabled(providedParams *options.ParameterBag) bool {
 
 func GetDeleteTunnelParamReqs() []ParameterRequirement {
 	return []ParameterRequirement{
+		GetNoPromptParamReq(),
 		{
 			Field:       ClientID,
 			Description: "[conditionally required] client id, if not provided, client name should be given",
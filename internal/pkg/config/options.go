@@ -13,6 +13,8 @@ const (
 	StringRequirementType      = "string"
 	IntRequirementType         = "int"
 	StringSliceRequirementType = "stringslice"
+	// StringArrayRequirementType is a repeatable flag whose values are taken as is, without splitting them by commas
+	StringArrayRequirementType = "stringarray"
 )
 
 // Validate validation callback
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	options "github.com/breathbath/go_utils/v2/pkg/config"
 	"github.com/breathbath/go_utils/v2/pkg/env"
@@ -27,6 +29,50 @@ func ReadClientNames(params *options.ParameterBag) (names string) {
 	return names
 }
 
+// ReadReports returns the FORMAT=FILE values of --report given as repeated flags or as a comma separated list
+func ReadReports(params *options.ParameterBag) (reports []string) {
+	for _, value := range params.ReadStrings(Report) {
+		for _, report := range strings.Split(value, ",") {
+			report = strings.TrimSpace(report)
+			if report != "" {
+				reports = append(reports, report)
+			}
+		}
+	}
+	return reports
+}
+
+// ReadScriptArgs returns the positional arguments of the script given by repeated --arg flags or by args in yaml
+func ReadScriptArgs(params *options.ParameterBag) []string {
+	return params.ReadStrings(ScriptArg)
+}
+
+// ReadScriptEnv returns the KEY=VALUE environment variables of the script given by repeated --env flags
+// or by env in yaml, the variables of yaml are ordered by name
+func ReadScriptEnv(params *options.ParameterBag) []string {
+	val, found := params.Read(ScriptEnv, nil)
+	if !found {
+		return nil
+	}
+
+	envMap, ok := val.(map[string]string)
+	if !ok {
+		return params.ReadStrings(ScriptEnv)
+	}
+
+	keys := make([]string, 0, len(envMap))
+	for key := range envMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+envMap[key])
+	}
+	return env
+}
+
 func ExecLogRequested(params *options.ParameterBag) (requested bool, logFilename string) {
 	logFilename = params.ReadString(WriteExecLog, "")
 	return logFilename != "", logFilename
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 )
 
@@ -25,16 +26,45 @@ type YAMLExecuteParams struct {
 	FullCommandResponse bool              `yaml:"full-command-response,omitempty"`
 	IsSudo              bool              `yaml:"is_sudo,omitempty"`
 	Interpreter         string            `yaml:"interpreter,omitempty"`
+	Args                []string          `yaml:"args,omitempty"`
+	Env                 map[string]string `yaml:"env,omitempty"`
 	AbortOnError        bool              `yaml:"abort,omitempty"`
 	Cwd                 string            `yaml:"cwd,omitempty"`
 	WriteExecLog        string            `yaml:"write-execlog,omitempty"`
 	ReadExecLog         string            `yaml:"read-execlog,omitempty"`
+	Detach              bool              `yaml:"detach,omitempty"`
+	Aggregate           bool              `yaml:"aggregate,omitempty"`
+	OutputDir           string            `yaml:"output-dir,omitempty"`
+	FailThreshold       string            `yaml:"fail-threshold,omitempty"`
+	Report              []string          `yaml:"report,omitempty"`
+	Retries             int               `yaml:"retries,omitempty"`
+	RetryDelay          string            `yaml:"retry-delay,omitempty"`
+	RetryBackoff        string            `yaml:"retry-backoff,omitempty"`
+	BatchSize           string            `yaml:"batch-size,omitempty"`
+	Canary              int               `yaml:"canary,omitempty"`
+	PauseBetween        string            `yaml:"pause-between,omitempty"`
+	MaxFailureRate      string            `yaml:"max-failure-rate,omitempty"`
+	Template            bool              `yaml:"template,omitempty"`
+	DryRun              bool              `yaml:"dry-run,omitempty"`
+	FromLibrary         string            `yaml:"from-library,omitempty"`
+	Cron                string            `yaml:"cron,omitempty"`
 }
 
 const (
 	expectedMaxYAMLParams = 32
 )
 
+// yamlParamNames maps the yaml keys which differ from the names of the flags they set
+var yamlParamNames = map[string]string{
+	"args": ScriptArg,
+}
+
+// yamlListParams are the list parameters which are kept as lists instead of being joined by commas,
+// because their values may contain commas themselves
+var yamlListParams = map[string]bool{
+	ScriptArg: true,
+}
+
 type UsedFlagsChecker interface {
 	ChangedFlag(flagName string) (isFound bool)
 }
@@ -43,6 +73,9 @@ func GetParamName(paramTag reflect.StructTag) (paramName string) {
 	yamlTag := paramTag.Get("yaml")
 	tagParts := strings.Split(yamlTag, ",")
 	paramName = tagParts[0]
+	if flagName, ok := yamlParamNames[paramName]; ok {
+		return flagName
+	}
 	return paramName
 }
 
@@ -70,7 +103,9 @@ func GetExecuteParams(ep *YAMLExecuteParams, yFileParams map[string]interface{},
 
 		if paramType == reflect.TypeOf([]string{}) {
 			paramStrings := paramValue.([]string)
-			if len(paramStrings) > 0 {
+			if len(paramStrings) > 0 && yamlListParams[paramName] {
+				yFileParams[paramName] = paramStrings
+			} else if len(paramStrings) > 0 {
 				// convert to comma delimited string, rather than array
 				yFileParams[paramName] = convertToDelimitedString(paramStrings)
 			}
@@ -129,3 +164,40 @@ func mapSearchParams(executeParams *YAMLExecuteParams) (*YAMLExecuteParams, erro
 	}
 	return executeParams, nil
 }
+
+// ExplicitParamsChecker tells if a parameter was changed on the command line or is set in one of the YAML files,
+// these parameters take precedence over values from other sources like the script library
+type ExplicitParamsChecker struct {
+	flagsChecker UsedFlagsChecker
+	yamlParams   map[string]bool
+}
+
+func NewExplicitParamsChecker(flags *pflag.FlagSet, yamlFiles []string) (*ExplicitParamsChecker, error) {
+	epc := &ExplicitParamsChecker{
+		flagsChecker: &FlagValuesProvider{flags: flags},
+		yamlParams:   make(map[string]bool),
+	}
+
+	for _, filename := range yamlFiles {
+		contents, err := os.ReadFile(strings.TrimSpace(filename))
+		if err != nil {
+			return nil, err
+		}
+
+		// only the keys present in the file count, YAMLExecuteParams can't tell unset from false
+		yParams := map[string]interface{}{}
+		err = yaml.Unmarshal(contents, &yParams)
+		if err != nil {
+			return nil, err
+		}
+		for name := range yParams {
+			epc.yamlParams[name] = true
+		}
+	}
+
+	return epc, nil
+}
+
+func (epc *ExplicitParamsChecker) ChangedFlag(name string) bool {
+	return epc.yamlParams[name] || epc.flagsChecker.ChangedFlag(name)
+}
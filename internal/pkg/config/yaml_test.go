@@ -101,3 +101,25 @@ func TestNoErrorOnScriptExecYAML(t *testing.T) {
 	assert.True(t, params.ReadBool(ExecConcurrently, false))
 	assert.Equal(t, params.ReadString(EmbeddedScript, ""), "pwd\nls\nls -la")
 }
+
+func TestScriptArgsAndEnvFromYAML(t *testing.T) {
+	testFile := "../../../testdata/test5-script-args.yaml"
+
+	rawParams, err := ReadYAMLExecuteParams([]string{testFile}, nil)
+	assert.NoError(t, err)
+
+	params := options.New(options.NewMapValuesProvider(rawParams))
+
+	assert.Equal(t, []string{"--mode", "fast, then slow"}, ReadScriptArgs(params))
+	assert.Equal(t, []string{"DEBUG=1", "TARGET=/opt/app"}, ReadScriptEnv(params))
+}
+
+func TestScriptArgsAndEnvFromFlags(t *testing.T) {
+	params := options.New(options.NewMapValuesProvider(map[string]interface{}{
+		ScriptArg: []string{"a,b", "c"},
+		ScriptEnv: []string{"TARGET=/opt/app"},
+	}))
+
+	assert.Equal(t, []string{"a,b", "c"}, ReadScriptArgs(params))
+	assert.Equal(t, []string{"TARGET=/opt/app"}, ReadScriptEnv(params))
+}
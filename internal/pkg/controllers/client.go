@@ -3,20 +3,28 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	options "github.com/breathbath/go_utils/v2/pkg/config"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/sirupsen/logrus"
 )
 
+const DefaultClientStatsBy = "os_family"
+
 type ClientRenderer interface {
 	RenderClients(clients []*models.Client) error
+	RenderClientsWithFields(clients []*models.Client, fields []string) error
 	RenderClient(client *models.Client, renderDetails bool) error
+	RenderClientStats(stats *models.ClientStats) error
 }
 
 type ClientController struct {
 	Rport          *api.Rport
 	ClientRenderer ClientRenderer
+	ClientPicker   ClientPicker
 }
 
 func (cc *ClientController) Clients(ctx context.Context, params *options.ParameterBag, searchFlags []string) error {
@@ -24,16 +32,116 @@ func (cc *ClientController) Clients(ctx context.Context, params *options.Paramet
 	if err != nil {
 		return err
 	}
-	clResp, err := cc.Rport.Clients(
+	fields, err := readClientFields(params)
+	if err != nil {
+		return err
+	}
+	sort, err := readClientSort(params)
+	if err != nil {
+		return err
+	}
+	requestedFields := fields
+	if len(requestedFields) == 0 {
+		requestedFields = api.DefaultClientFields
+	}
+
+	if params.ReadBool(api.PaginationAll, false) {
+		clients, e := cc.Rport.AllClientsWithFields(ctx, filter, requestedFields, sort)
+		if e != nil {
+			return e
+		}
+		return cc.ClientRenderer.RenderClientsWithFields(clients, fields)
+	}
+
+	pagination := api.NewPaginationFromParams(params)
+	clResp, err := cc.Rport.ClientsWithFields(
 		ctx,
-		api.NewPaginationFromParams(params),
+		pagination,
 		filter,
+		requestedFields,
+		sort,
 	)
 	if err != nil {
 		return err
 	}
 
-	return cc.ClientRenderer.RenderClients(clResp.Data)
+	if clResp.Truncated(pagination) {
+		logrus.Warnf(
+			"showing %d of %d matching clients, use --%s or --%s and --%s to see more",
+			len(clResp.Data),
+			clResp.Meta.Count,
+			api.PaginationAll,
+			api.PaginationLimit,
+			api.PaginationOffset,
+		)
+	}
+
+	return cc.ClientRenderer.RenderClientsWithFields(clResp.Data, fields)
+}
+
+// Stats counts all clients matching the search flags by the values of the fields given in --by
+func (cc *ClientController) Stats(ctx context.Context, params *options.ParameterBag, searchFlags []string) error {
+	filter, err := api.NewFilterFromKVStrings(searchFlags)
+	if err != nil {
+		return err
+	}
+
+	by := splitFieldsList(params.ReadString(config.ClientStatsBy, ""))
+	if len(by) == 0 {
+		by = []string{DefaultClientStatsBy}
+	}
+	for _, field := range by {
+		if !models.IsClientField(field) {
+			return unknownClientFieldError(field)
+		}
+	}
+
+	clients, err := cc.Rport.AllClientsWithFields(ctx, filter, append(api.Fields{"id"}, by...), nil)
+	if err != nil {
+		return err
+	}
+
+	return cc.ClientRenderer.RenderClientStats(models.NewClientStats(clients, by))
+}
+
+// readClientFields reads the comma separated list of client fields to fetch and render
+func readClientFields(params *options.ParameterBag) (api.Fields, error) {
+	fields := splitFieldsList(params.ReadString(config.ClientFields, ""))
+	for _, field := range fields {
+		if !models.IsClientField(field) {
+			return nil, unknownClientFieldError(field)
+		}
+	}
+
+	return fields, nil
+}
+
+// readClientSort reads the comma separated list of client fields to sort by, a '-' prefix sorts in descending order
+func readClientSort(params *options.ParameterBag) (api.Sort, error) {
+	sort := splitFieldsList(params.ReadString(config.ClientSort, ""))
+	for _, field := range sort {
+		if !models.IsClientField(strings.TrimPrefix(field, "-")) {
+			return nil, unknownClientFieldError(strings.TrimPrefix(field, "-"))
+		}
+	}
+
+	return sort, nil
+}
+
+func splitFieldsList(list string) []string {
+	fields := make([]string, 0)
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func unknownClientFieldError(field string) error {
+	return fmt.Errorf("unknown client field %q, supported fields are: %s", field, strings.Join(models.ClientFieldNames(), ", "))
 }
 
 func (cc *ClientController) Client(ctx context.Context, params *options.ParameterBag, id, name string) error {
@@ -51,16 +159,42 @@ func (cc *ClientController) Client(ctx context.Context, params *options.Paramete
 		return cc.ClientRenderer.RenderClient(client, renderDetails)
 	}
 
-	clients, err := cc.Rport.Clients(ctx, api.NewPaginationWithLimit(2), api.NewFilters("name", name))
+	client, err := findClientByName(ctx, cc.Rport, name, pickerUnlessNoPrompt(cc.ClientPicker, params))
 	if err != nil {
 		return err
 	}
-	if len(clients.Data) < 1 {
-		return fmt.Errorf("unknown client with name %q", name)
-	}
-	if len(clients.Data) > 1 {
-		return fmt.Errorf("client with name %q is ambiguous, use a more precise name or use the client id", name)
+
+	return cc.ClientRenderer.RenderClient(client, renderDetails)
+}
+
+const maxClientsForSelection = 25
+
+// findClientByName returns the only client matching the name, an error is returned if the name is unknown or ambiguous.
+// If a picker is given, the user chooses one of the clients matching an ambiguous name.
+func findClientByName(ctx context.Context, rport *api.Rport, name string, picker ClientPicker) (*models.Client, error) {
+	clients, err := rport.Clients(ctx, api.NewPaginationWithLimit(maxClientsForSelection+1), api.NewFilters("name", name))
+	if err != nil {
+		return nil, err
 	}
 
-	return cc.ClientRenderer.RenderClient(clients.Data[0], renderDetails)
+	switch numClients := len(clients.Data); {
+	case numClients == 1:
+		return clients.Data[0], nil
+	case numClients < 1:
+		return nil, fmt.Errorf("unknown client with name %q", name)
+	case numClients > maxClientsForSelection:
+		return nil, fmt.Errorf("client with name %q is ambiguous, use a more precise name or use the client id", name)
+	case picker != nil:
+		return picker.PickClient(name, clients.Data)
+	default:
+		names := []string{}
+		for _, client := range clients.Data {
+			names = append(names, "- "+client.Name)
+		}
+		return nil, fmt.Errorf(
+			"client with name %q is ambiguous, use a more precise name or use the client id.\ndo you mean:\n%s",
+			name,
+			strings.Join(names, "\n"),
+		)
+	}
 }
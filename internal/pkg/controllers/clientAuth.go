@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+const clientAuthPasswordLength = 32
+
+type ClientAuthRenderer interface {
+	RenderClientAuths(auths []*models.ClientAuth) error
+	RenderClientAuthConfig(authConfig *models.ClientAuthConfig) error
+	RenderDelete(s output.KvProvider) error
+}
+
+type ClientAuthController struct {
+	Rport              *api.Rport
+	ClientAuthRenderer ClientAuthRenderer
+}
+
+func (cac *ClientAuthController) ClientAuths(ctx context.Context, params *options.ParameterBag) error {
+	pagination := api.NewPaginationFromParams(params)
+	resp, err := cac.Rport.ClientAuths(ctx, pagination)
+	if err != nil {
+		return err
+	}
+
+	if resp.Truncated(pagination) {
+		logrus.Warnf(
+			"showing %d of %d client auth credentials, use --%s and --%s to see more",
+			len(resp.Data),
+			resp.Meta.Count,
+			api.PaginationLimit,
+			api.PaginationOffset,
+		)
+	}
+
+	return cac.ClientAuthRenderer.RenderClientAuths(resp.Data)
+}
+
+// Create creates client auth credentials with a random password and renders the client config to use them
+func (cac *ClientAuthController) Create(ctx context.Context, params *options.ParameterBag, id string) error {
+	if id == "" {
+		return errors.New("no client auth id provided")
+	}
+	// the client sends the credentials as id:password
+	if strings.Contains(id, ":") {
+		return errors.New("client auth id must not contain ':'")
+	}
+
+	password, err := utils.GeneratePassword(clientAuthPasswordLength)
+	if err != nil {
+		return err
+	}
+
+	auth := models.ClientAuth{ID: id, Password: password}
+	err = cac.Rport.CreateClientAuth(ctx, &auth)
+	if err != nil {
+		return err
+	}
+
+	return cac.ClientAuthRenderer.RenderClientAuthConfig(models.NewClientAuthConfig(auth, config.ReadAPIURL(params)))
+}
+
+func (cac *ClientAuthController) Delete(ctx context.Context, params *options.ParameterBag, id string) error {
+	if id == "" {
+		return errors.New("no client auth id provided")
+	}
+
+	err := cac.Rport.DeleteClientAuth(ctx, id, params.ReadBool(config.ForceDeletion, false))
+	if err != nil {
+		return err
+	}
+
+	return cac.ClientAuthRenderer.RenderDelete(&models.OperationStatus{Status: "Client auth credentials successfully deleted"})
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+type ClientAuthRendererMock struct {
+	auths         []*models.ClientAuth
+	authConfig    *models.ClientAuthConfig
+	deleteMessage string
+}
+
+func (carm *ClientAuthRendererMock) RenderClientAuths(auths []*models.ClientAuth) error {
+	carm.auths = auths
+	return nil
+}
+
+func (carm *ClientAuthRendererMock) RenderClientAuthConfig(authConfig *models.ClientAuthConfig) error {
+	carm.authConfig = authConfig
+	return nil
+}
+
+func (carm *ClientAuthRendererMock) RenderDelete(s output.KvProvider) error {
+	carm.deleteMessage = s.KeyValues()[0].Value
+	return nil
+}
+
+func TestClientAuthCreate(t *testing.T) {
+	createdAuth := &models.ClientAuth{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(createdAuth))
+		rw.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientAuthRendererMock{}
+	cac := &ClientAuthController{
+		Rport:              api.New(srv.URL, nil),
+		ClientAuthRenderer: renderer,
+	}
+
+	params := config.FromValues(map[string]string{config.APIURL: "https://rport.example.com"})
+	err := cac.Create(context.Background(), params, "web")
+	require.NoError(t, err)
+
+	assert.Equal(t, "web", createdAuth.ID)
+	assert.Len(t, createdAuth.Password, clientAuthPasswordLength)
+	require.NotNil(t, renderer.authConfig)
+	assert.Equal(t, *createdAuth, renderer.authConfig.ClientAuth)
+	assert.Equal(
+		t,
+		"[client]\n  server = \"https://rport.example.com\"\n  auth = \"web:"+createdAuth.Password+"\"\n",
+		renderer.authConfig.RportConf,
+	)
+}
+
+func TestClientAuthCreateRejectsInvalidID(t *testing.T) {
+	cac := &ClientAuthController{}
+
+	err := cac.Create(context.Background(), config.FromValues(map[string]string{}), "web:1")
+	assert.EqualError(t, err, "client auth id must not contain ':'")
+}
+
+func TestClientAuthDelete(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "", r.URL.Query().Get("force"))
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientAuthRendererMock{}
+	cac := &ClientAuthController{
+		Rport:              api.New(srv.URL, nil),
+		ClientAuthRenderer: renderer,
+	}
+
+	err := cac.Delete(context.Background(), config.FromValues(map[string]string{}), "web")
+	require.NoError(t, err)
+	assert.Equal(t, "Client auth credentials successfully deleted", renderer.deleteMessage)
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+const (
+	WillBeDeletedClientsMsg = "The following disconnected clients will be deleted:"
+	proceedWithDeletionMsg  = "Proceed with deleting the above clients (y/n): "
+	ClientDeleted           = "deleted"
+	ClientDeleteFailed      = "failed"
+)
+
+var ErrClientsDeletionNotConfirmed = errors.New("deletion of clients not confirmed")
+
+type ClientDeleteRenderer interface {
+	RenderDeleteResults(results []*models.ClientOperationResult) error
+}
+
+type ClientDeleteController struct {
+	Rport                *api.Rport
+	ClientDeleteRenderer ClientDeleteRenderer
+	PromptReader         config.PromptReader
+	Now                  func() time.Time
+}
+
+// Delete deletes a single client identified by its id or name
+func (cdc *ClientDeleteController) Delete(ctx context.Context, params *options.ParameterBag, id string) error {
+	client := &models.Client{ID: id}
+	if id == "" {
+		name := params.ReadString(config.ClientNameFlag, "")
+		if name == "" {
+			return errors.New("no client id or name provided")
+		}
+
+		cl, err := findClientByName(ctx, cdc.Rport, name, nil)
+		if err != nil {
+			return err
+		}
+		client = cl
+	}
+
+	err := cdc.Rport.DeleteClient(ctx, client.ID)
+	if err != nil {
+		return err
+	}
+
+	return cdc.ClientDeleteRenderer.RenderDeleteResults([]*models.ClientOperationResult{
+		{ClientID: client.ID, ClientName: client.Name, Status: ClientDeleted},
+	})
+}
+
+// Prune deletes all clients matching the search flags which are disconnected for longer than the given duration
+func (cdc *ClientDeleteController) Prune(ctx context.Context, params *options.ParameterBag, searchFlags []string) error {
+	disconnectedFor, err := readDisconnectedFor(params)
+	if err != nil {
+		return err
+	}
+
+	filter, err := api.NewFilterFromKVStrings(searchFlags)
+	if err != nil {
+		return err
+	}
+	filter["connection_state"] = models.ClientConnStateDisconnected
+
+	clients, err := cdc.Rport.AllClientsWithFields(
+		ctx,
+		filter,
+		api.Fields{"id", "name", "connection_state", "disconnected_at"},
+		api.Sort{"name"},
+	)
+	if err != nil {
+		return err
+	}
+
+	candidates := findPruneCandidates(clients, cdc.now().Add(-disconnectedFor))
+	if len(candidates) == 0 {
+		return cdc.ClientDeleteRenderer.RenderDeleteResults([]*models.ClientOperationResult{})
+	}
+
+	err = cdc.confirmDeletion(params, candidates)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*models.ClientOperationResult, 0, len(candidates))
+	failed := 0
+	for _, cl := range candidates {
+		result := &models.ClientOperationResult{
+			ClientID:   cl.ID,
+			ClientName: cl.Name,
+			Status:     ClientDeleted,
+		}
+		if e := cdc.Rport.DeleteClient(ctx, cl.ID); e != nil {
+			result.Status = ClientDeleteFailed
+			result.Error = e.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	err = cdc.ClientDeleteRenderer.RenderDeleteResults(results)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d clients", failed, len(candidates))
+	}
+
+	return nil
+}
+
+func (cdc *ClientDeleteController) now() time.Time {
+	if cdc.Now != nil {
+		return cdc.Now()
+	}
+	return time.Now()
+}
+
+func (cdc *ClientDeleteController) confirmDeletion(params *options.ParameterBag, candidates []*models.Client) error {
+	if cdc.PromptReader == nil || config.ReadNoPrompt(params) {
+		return nil
+	}
+
+	cdc.PromptReader.Output(WillBeDeletedClientsMsg + "\n")
+	for _, cl := range candidates {
+		cdc.PromptReader.Output(fmt.Sprintf("%s: %s, disconnected at %s\n", cl.ID, cl.Name, cl.DisconnectedAt))
+	}
+
+	proceed, err := cdc.PromptReader.ReadConfirmation(proceedWithDeletionMsg)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return ErrClientsDeletionNotConfirmed
+	}
+
+	return nil
+}
+
+func readDisconnectedFor(params *options.ParameterBag) (time.Duration, error) {
+	disconnectedForStr := params.ReadString(config.DisconnectedFor, "")
+	if disconnectedForStr == "" {
+		return 0, fmt.Errorf("--%s is required, e.g. 30d", config.DisconnectedFor)
+	}
+
+	disconnectedFor, err := utils.ParseDuration(disconnectedForStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --%s value: %v", config.DisconnectedFor, err)
+	}
+	if disconnectedFor <= 0 {
+		return 0, fmt.Errorf("--%s must be positive", config.DisconnectedFor)
+	}
+
+	return disconnectedFor, nil
+}
+
+// findPruneCandidates returns the disconnected clients which have been disconnected before the given time
+func findPruneCandidates(clients []*models.Client, disconnectedBefore time.Time) []*models.Client {
+	candidates := make([]*models.Client, 0)
+	for _, cl := range clients {
+		if cl.ConnState != models.ClientConnStateDisconnected || cl.DisconnectedAt == "" {
+			continue
+		}
+
+		disconnectedAt, err := time.Parse(time.RFC3339, cl.DisconnectedAt)
+		if err != nil {
+			logrus.Warnf("skipping client %s [%s], cannot parse disconnected_at %q: %v", cl.Name, cl.ID, cl.DisconnectedAt, err)
+			continue
+		}
+
+		if disconnectedAt.Before(disconnectedBefore) {
+			candidates = append(candidates, cl)
+		}
+	}
+
+	return candidates
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientDeleteRendererMock struct {
+	results []*models.ClientOperationResult
+}
+
+func (cdrm *ClientDeleteRendererMock) RenderDeleteResults(results []*models.ClientOperationResult) error {
+	cdrm.results = results
+	return nil
+}
+
+var pruneNow = time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func startPruneServer(t *testing.T, deleted *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "disconnected", r.URL.Query().Get("filter[connection_state]"))
+			assert.Equal(t, "windows", r.URL.Query().Get("filter[os_kernel]"))
+			e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{
+				{ID: "1", Name: "old1", ConnState: models.ClientConnStateDisconnected, DisconnectedAt: "2022-01-01T00:00:00Z"},
+				{ID: "2", Name: "recent", ConnState: models.ClientConnStateDisconnected, DisconnectedAt: "2022-02-27T00:00:00Z"},
+				{ID: "3", Name: "old2", ConnState: models.ClientConnStateDisconnected, DisconnectedAt: "2022-01-15T00:00:00+02:00"},
+				{ID: "4", Name: "broken", ConnState: models.ClientConnStateDisconnected, DisconnectedAt: "yesterday"},
+			}})
+			assert.NoError(t, e)
+			return
+		}
+
+		assert.Equal(t, http.MethodDelete, r.Method)
+		clientID := strings.TrimPrefix(r.URL.Path, "/api/v1/clients/")
+		*deleted = append(*deleted, clientID)
+		if clientID == "3" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func TestClientPrune(t *testing.T) {
+	deleted := make([]string, 0)
+	srv := startPruneServer(t, &deleted)
+	defer srv.Close()
+
+	renderer := &ClientDeleteRendererMock{}
+	cdc := &ClientDeleteController{
+		Rport:                api.New(srv.URL, nil),
+		ClientDeleteRenderer: renderer,
+		PromptReader:         &execPromptReaderMock{ConfirmationAnswer: true},
+		Now: func() time.Time {
+			return pruneNow
+		},
+	}
+
+	params := config.FromValues(map[string]string{config.DisconnectedFor: "30d"})
+	err := cdc.Prune(context.Background(), params, []string{"os_kernel=windows"})
+	require.EqualError(t, err, "failed to delete 1 of 2 clients")
+
+	assert.Equal(t, []string{"1", "3"}, deleted)
+	require.Len(t, renderer.results, 2)
+	assert.Equal(t, ClientDeleted, renderer.results[0].Status)
+	assert.Equal(t, "old2", renderer.results[1].ClientName)
+	assert.Equal(t, ClientDeleteFailed, renderer.results[1].Status)
+}
+
+func TestClientPruneNotConfirmed(t *testing.T) {
+	deleted := make([]string, 0)
+	srv := startPruneServer(t, &deleted)
+	defer srv.Close()
+
+	cdc := &ClientDeleteController{
+		Rport:                api.New(srv.URL, nil),
+		ClientDeleteRenderer: &ClientDeleteRendererMock{},
+		PromptReader:         &execPromptReaderMock{ConfirmationAnswer: false},
+		Now: func() time.Time {
+			return pruneNow
+		},
+	}
+
+	params := config.FromValues(map[string]string{config.DisconnectedFor: "30d"})
+	err := cdc.Prune(context.Background(), params, []string{"os_kernel=windows"})
+	assert.ErrorIs(t, err, ErrClientsDeletionNotConfirmed)
+	assert.Empty(t, deleted)
+
+	params = config.FromValues(map[string]string{config.DisconnectedFor: "30d", config.NoPrompt: "true"})
+	err = cdc.Prune(context.Background(), params, []string{"os_kernel=windows"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1", "3"}, deleted)
+}
+
+func TestClientPruneRequiresDuration(t *testing.T) {
+	cdc := &ClientDeleteController{}
+
+	err := cdc.Prune(context.Background(), config.FromValues(map[string]string{}), nil)
+	assert.EqualError(t, err, "--disconnected-for is required, e.g. 30d")
+
+	err = cdc.Prune(context.Background(), config.FromValues(map[string]string{config.DisconnectedFor: "1month"}), nil)
+	assert.Error(t, err)
+}
+
+func TestClientDeleteByName(t *testing.T) {
+	deleted := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "old1", r.URL.Query().Get("filter[name]"))
+			e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{{ID: "1", Name: "old1"}}})
+			assert.NoError(t, e)
+			return
+		}
+		deleted = r.URL.Path
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientDeleteRendererMock{}
+	cdc := &ClientDeleteController{
+		Rport:                api.New(srv.URL, nil),
+		ClientDeleteRenderer: renderer,
+	}
+
+	err := cdc.Delete(context.Background(), config.FromValues(map[string]string{config.ClientNameFlag: "old1"}), "")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/clients/1", deleted)
+	assert.Equal(t, []*models.ClientOperationResult{{ClientID: "1", ClientName: "old1", Status: ClientDeleted}}, renderer.results)
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+var ErrNoClientGroupParams = fmt.Errorf("no client group params provided, use --%s key=value", config.ClientSearchFlag)
+
+type ClientGroupRenderer interface {
+	RenderClientGroups(groups []*models.ClientGroup) error
+	RenderClientGroup(group *models.ClientGroup) error
+	RenderDelete(s output.KvProvider) error
+}
+
+type ClientGroupController struct {
+	Rport               *api.Rport
+	ClientGroupRenderer ClientGroupRenderer
+	ClientRenderer      ClientRenderer
+}
+
+func (cgc *ClientGroupController) ClientGroups(ctx context.Context) error {
+	resp, err := cgc.Rport.ClientGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cgc.ClientGroupRenderer.RenderClientGroups(resp.Data)
+}
+
+func (cgc *ClientGroupController) ClientGroup(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("no client group id provided")
+	}
+
+	group, err := cgc.Rport.ClientGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return cgc.ClientGroupRenderer.RenderClientGroup(group)
+}
+
+// Members renders the clients which currently belong to the group
+func (cgc *ClientGroupController) Members(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("no client group id provided")
+	}
+
+	group, err := cgc.Rport.ClientGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(group.ClientIDs) == 0 {
+		return cgc.ClientRenderer.RenderClients([]*models.Client{})
+	}
+
+	clients, err := cgc.Rport.AllClients(ctx, api.NewFilters("groups", group.ID))
+	if err != nil {
+		return err
+	}
+
+	return cgc.ClientRenderer.RenderClients(clients)
+}
+
+func (cgc *ClientGroupController) Create(ctx context.Context, params *options.ParameterBag, id string, searchFlags []string) error {
+	if id == "" {
+		return errors.New("no client group id provided")
+	}
+
+	groupParams, err := cgc.readGroupParams(searchFlags)
+	if err != nil {
+		return err
+	}
+	if len(groupParams) == 0 {
+		return ErrNoClientGroupParams
+	}
+
+	group := &models.ClientGroup{
+		ID:          id,
+		Description: params.ReadString(config.Description, ""),
+		Params:      groupParams,
+	}
+	err = cgc.Rport.CreateClientGroup(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	// read the group back to show the client ids matched by the server
+	return cgc.ClientGroup(ctx, id)
+}
+
+func (cgc *ClientGroupController) Update(ctx context.Context, params *options.ParameterBag, id string, searchFlags []string) error {
+	if id == "" {
+		return errors.New("no client group id provided")
+	}
+
+	group, err := cgc.Rport.ClientGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	groupParams, err := cgc.readGroupParams(searchFlags)
+	if err != nil {
+		return err
+	}
+
+	description := params.ReadString(config.Description, "")
+	if len(groupParams) == 0 && description == "" {
+		return fmt.Errorf("nothing to update, use --%s or --%s", config.Description, config.ClientSearchFlag)
+	}
+	if len(groupParams) > 0 {
+		group.Params = groupParams
+	}
+	if description != "" {
+		group.Description = description
+	}
+
+	err = cgc.Rport.UpdateClientGroup(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	return cgc.ClientGroup(ctx, id)
+}
+
+func (cgc *ClientGroupController) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("no client group id provided")
+	}
+
+	err := cgc.Rport.DeleteClientGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return cgc.ClientGroupRenderer.RenderDelete(&models.OperationStatus{Status: "Client group successfully deleted"})
+}
+
+func (cgc *ClientGroupController) readGroupParams(searchFlags []string) (models.ClientGroupParams, error) {
+	filter, err := api.NewFilterFromKVStrings(searchFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewClientGroupParamsFromFilters(filter), nil
+}
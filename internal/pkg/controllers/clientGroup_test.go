@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+type ClientGroupRendererMock struct {
+	groups        []*models.ClientGroup
+	group         *models.ClientGroup
+	deleteMessage string
+}
+
+func (cgrm *ClientGroupRendererMock) RenderClientGroups(groups []*models.ClientGroup) error {
+	cgrm.groups = groups
+	return nil
+}
+
+func (cgrm *ClientGroupRendererMock) RenderClientGroup(group *models.ClientGroup) error {
+	cgrm.group = group
+	return nil
+}
+
+func (cgrm *ClientGroupRendererMock) RenderDelete(s output.KvProvider) error {
+	cgrm.deleteMessage = s.KeyValues()[0].Value
+	return nil
+}
+
+func TestClientGroupCreate(t *testing.T) {
+	var createdBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			createdBody = string(body)
+			rw.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			assert.Equal(t, "/api/v1/client-groups/debian", r.URL.String())
+			e := json.NewEncoder(rw).Encode(api.ClientGroupResponse{Data: &models.ClientGroup{
+				ID:        "debian",
+				ClientIDs: []string{"123"},
+			}})
+			assert.NoError(t, e)
+		}
+	}))
+	defer srv.Close()
+
+	renderer := &ClientGroupRendererMock{}
+	cgc := &ClientGroupController{
+		Rport:               api.New(srv.URL, nil),
+		ClientGroupRenderer: renderer,
+	}
+
+	params := config.FromValues(map[string]string{config.Description: "debian clients"})
+	err := cgc.Create(context.Background(), params, "debian", []string{"os_family=debian", "name=web1,web2"})
+	require.NoError(t, err)
+
+	assert.JSONEq(
+		t,
+		`{"id":"debian","description":"debian clients","params":{"name":["web1","web2"],"os_family":["debian"]}}`,
+		createdBody,
+	)
+	require.NotNil(t, renderer.group)
+	assert.Equal(t, []string{"123"}, renderer.group.ClientIDs)
+}
+
+func TestClientGroupCreateWithoutParams(t *testing.T) {
+	cgc := &ClientGroupController{}
+
+	err := cgc.Create(context.Background(), &options.ParameterBag{}, "debian", []string{})
+	assert.ErrorIs(t, err, ErrNoClientGroupParams)
+}
+
+func TestClientGroupUpdateKeepsUnchangedFields(t *testing.T) {
+	var updatedBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			updatedBody = string(body)
+			rw.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			e := json.NewEncoder(rw).Encode(api.ClientGroupResponse{Data: &models.ClientGroup{
+				ID:          "debian",
+				Description: "old description",
+				Params:      models.ClientGroupParams{"os_family": {"debian"}},
+				ClientIDs:   []string{"123"},
+			}})
+			assert.NoError(t, e)
+		}
+	}))
+	defer srv.Close()
+
+	cgc := &ClientGroupController{
+		Rport:               api.New(srv.URL, nil),
+		ClientGroupRenderer: &ClientGroupRendererMock{},
+	}
+
+	params := config.FromValues(map[string]string{config.Description: "new description"})
+	err := cgc.Update(context.Background(), params, "debian", []string{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"id":"debian","description":"new description","params":{"os_family":["debian"]}}`, updatedBody)
+}
+
+func TestClientGroupMembers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		jsonEnc := json.NewEncoder(rw)
+		if r.URL.Path == api.ClientsURL {
+			assert.Equal(t, "debian", r.URL.Query().Get("filter[groups]"))
+			assert.NoError(t, jsonEnc.Encode(api.ClientsResponse{Data: []*models.Client{clientStub}}))
+			return
+		}
+		assert.NoError(t, jsonEnc.Encode(api.ClientGroupResponse{Data: &models.ClientGroup{
+			ID:        "debian",
+			ClientIDs: []string{"123", "124"},
+		}}))
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	cgc := &ClientGroupController{
+		Rport:          api.New(srv.URL, nil),
+		ClientRenderer: &ClientRendererMock{Writer: &buf},
+	}
+
+	err := cgc.Members(context.Background(), "debian")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"id":"123"`)
+}
+
+func TestClientGroupDeleteController(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientGroupRendererMock{}
+	cgc := &ClientGroupController{
+		Rport:               api.New(srv.URL, nil),
+		ClientGroupRenderer: renderer,
+	}
+
+	err := cgc.Delete(context.Background(), "debian")
+	require.NoError(t, err)
+	assert.Equal(t, "Client group successfully deleted", renderer.deleteMessage)
+}
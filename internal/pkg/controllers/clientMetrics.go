@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+const DefaultMetricsSince = "1h"
+
+type ClientMetricsRenderer interface {
+	RenderClientMetrics(report *models.ClientMetricsReport) error
+}
+
+type ClientMetricsController struct {
+	Rport                 *api.Rport
+	ClientMetricsRenderer ClientMetricsRenderer
+	Now                   func() time.Time
+}
+
+// Metrics renders the monitoring data of a client identified by its id or name within the --since and --until range
+func (cmc *ClientMetricsController) Metrics(ctx context.Context, params *options.ParameterBag, id string) error {
+	since, until, err := readTimeRange(params, DefaultMetricsSince, cmc.now())
+	if err != nil {
+		return err
+	}
+
+	report := &models.ClientMetricsReport{
+		ClientID: id,
+		Since:    since,
+		Until:    until,
+	}
+	if id == "" {
+		name := params.ReadString(config.ClientNameFlag, "")
+		if name == "" {
+			return errors.New("no client id or name provided")
+		}
+
+		cl, e := findClientByName(ctx, cmc.Rport, name, nil)
+		if e != nil {
+			return e
+		}
+		report.ClientID = cl.ID
+		report.ClientName = cl.Name
+	}
+
+	report.Metrics, err = cmc.Rport.ClientMetrics(ctx, report.ClientID, since, until)
+	if err != nil {
+		return err
+	}
+
+	return cmc.ClientMetricsRenderer.RenderClientMetrics(report)
+}
+
+func (cmc *ClientMetricsController) now() time.Time {
+	if cmc.Now != nil {
+		return cmc.Now()
+	}
+	return time.Now()
+}
+
+// readTimeRange reads --since and --until given as timestamps or as durations before now, until defaults to now
+func readTimeRange(params *options.ParameterBag, defaultSince string, now time.Time) (since, until time.Time, err error) {
+	sinceStr := params.ReadString(config.Since, "")
+	if sinceStr == "" {
+		sinceStr = defaultSince
+	}
+	since, err = utils.ParseTimeOrAgo(sinceStr, now)
+	if err != nil {
+		return since, until, fmt.Errorf("invalid --%s value: %v", config.Since, err)
+	}
+
+	until = now
+	if untilStr := params.ReadString(config.Until, ""); untilStr != "" {
+		until, err = utils.ParseTimeOrAgo(untilStr, now)
+		if err != nil {
+			return since, until, fmt.Errorf("invalid --%s value: %v", config.Until, err)
+		}
+	}
+
+	if !since.Before(until) {
+		return since, until, fmt.Errorf("--%s must be before --%s", config.Since, config.Until)
+	}
+
+	return since, until, nil
+}
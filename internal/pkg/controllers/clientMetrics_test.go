@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientMetricsRendererMock struct {
+	report *models.ClientMetricsReport
+}
+
+func (cmrm *ClientMetricsRendererMock) RenderClientMetrics(report *models.ClientMetricsReport) error {
+	cmrm.report = report
+	return nil
+}
+
+func TestClientMetricsByName(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		jsonEnc := json.NewEncoder(rw)
+		if r.URL.Path == api.ClientsURL {
+			assert.Equal(t, "web1", r.URL.Query().Get("filter[name]"))
+			assert.NoError(t, jsonEnc.Encode(api.ClientsResponse{Data: []*models.Client{{ID: "123", Name: "web1"}}}))
+			return
+		}
+
+		assert.Equal(t, "/api/v1/clients/123/metrics", r.URL.Path)
+		assert.Equal(t, "2022-03-01T10:00:00Z", r.URL.Query().Get("filter[timestamp][since]"))
+		assert.Equal(t, "2022-03-01T11:00:00Z", r.URL.Query().Get("filter[timestamp][until]"))
+		assert.NoError(t, jsonEnc.Encode(api.ClientMetricsResponse{Data: []*models.ClientMetrics{
+			{Timestamp: now, CPUUsagePercent: 12.5},
+		}}))
+	}))
+	defer srv.Close()
+
+	renderer := &ClientMetricsRendererMock{}
+	cmc := &ClientMetricsController{
+		Rport:                 api.New(srv.URL, nil),
+		ClientMetricsRenderer: renderer,
+		Now: func() time.Time {
+			return now
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientNameFlag: "web1",
+		config.Since:          "2h",
+		config.Until:          "2022-03-01T11:00:00Z",
+	})
+	err := cmc.Metrics(context.Background(), params, "")
+	require.NoError(t, err)
+
+	require.NotNil(t, renderer.report)
+	assert.Equal(t, "123", renderer.report.ClientID)
+	assert.Equal(t, "web1", renderer.report.ClientName)
+	require.Len(t, renderer.report.Metrics, 1)
+	assert.Equal(t, 12.5, renderer.report.Metrics[0].CPUUsagePercent)
+}
+
+func TestReadTimeRange(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	since, until, err := readTimeRange(config.FromValues(map[string]string{}), "1h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), since)
+	assert.Equal(t, now, until)
+
+	_, _, err = readTimeRange(config.FromValues(map[string]string{config.Since: "1h", config.Until: "2h"}), "1h", now)
+	assert.EqualError(t, err, "--since must be before --until")
+
+	_, _, err = readTimeRange(config.FromValues(map[string]string{config.Since: "last week"}), "1h", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+// ClientPicker lets the user choose one of several clients matching an ambiguous name
+type ClientPicker interface {
+	PickClient(name string, clients []*models.Client) (*models.Client, error)
+}
+
+type Selector interface {
+	Select(title string, items []string) (int, error)
+}
+
+// SelectClientPicker shows the clients as aligned rows of name, hostname, address and connection state
+type SelectClientPicker struct {
+	Selector Selector
+}
+
+func (scp *SelectClientPicker) PickClient(name string, clients []*models.Client) (*models.Client, error) {
+	index, err := scp.Selector.Select(
+		fmt.Sprintf("Client name %q is ambiguous, choose a client:", name),
+		formatClientChoices(clients),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients[index], nil
+}
+
+func formatClientChoices(clients []*models.Client) []string {
+	nameWidth, hostnameWidth, addressWidth := 0, 0, 0
+	for _, cl := range clients {
+		nameWidth = maxInt(nameWidth, utf8.RuneCountInString(cl.Name))
+		hostnameWidth = maxInt(hostnameWidth, utf8.RuneCountInString(cl.Hostname))
+		addressWidth = maxInt(addressWidth, utf8.RuneCountInString(cl.Address))
+	}
+
+	choices := make([]string, 0, len(clients))
+	for _, cl := range clients {
+		choices = append(choices, fmt.Sprintf(
+			"%-*s  %-*s  %-*s  %s",
+			nameWidth, cl.Name,
+			hostnameWidth, cl.Hostname,
+			addressWidth, cl.Address,
+			cl.ConnState,
+		))
+	}
+
+	return choices
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// pickerUnlessNoPrompt disables the picker when prompting is turned off
+func pickerUnlessNoPrompt(picker ClientPicker, params *options.ParameterBag) ClientPicker {
+	if picker == nil || config.ReadNoPrompt(params) {
+		return nil
+	}
+	return picker
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type SelectorMock struct {
+	title       string
+	items       []string
+	indexToPick int
+}
+
+func (sm *SelectorMock) Select(title string, items []string) (int, error) {
+	sm.title = title
+	sm.items = items
+	return sm.indexToPick, nil
+}
+
+var ambiguousClients = []*models.Client{
+	{ID: "1", Name: "web", Hostname: "web.example.com", Address: "1.1.1.1", ConnState: "connected"},
+	{ID: "2", Name: "webserver", Hostname: "www", Address: "10.10.10.10", ConnState: "disconnected"},
+}
+
+func startAmbiguousClientsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ClientsURL {
+			assert.Equal(t, "web*", r.URL.Query().Get("filter[name]"))
+			assert.NoError(t, json.NewEncoder(rw).Encode(api.ClientsResponse{Data: ambiguousClients}))
+			return
+		}
+		assert.Fail(t, "unexpected request", r.URL.String())
+	}))
+}
+
+func TestClientPickedWhenNameIsAmbiguous(t *testing.T) {
+	srv := startAmbiguousClientsServer(t)
+	defer srv.Close()
+
+	selector := &SelectorMock{indexToPick: 1}
+	buf := bytes.Buffer{}
+	cc := &ClientController{
+		Rport:          api.New(srv.URL, nil),
+		ClientRenderer: &ClientRendererMock{Writer: &buf},
+		ClientPicker:   &SelectClientPicker{Selector: selector},
+	}
+
+	err := cc.Client(context.Background(), config.FromValues(map[string]string{}), "", "web*")
+	require.NoError(t, err)
+
+	assert.Equal(t, `Client name "web*" is ambiguous, choose a client:`, selector.title)
+	assert.Equal(t, []string{
+		"web        web.example.com  1.1.1.1      connected",
+		"webserver  www              10.10.10.10  disconnected",
+	}, selector.items)
+	assert.Contains(t, buf.String(), `"id":"2"`)
+}
+
+func TestClientNotPickedWithNoPrompt(t *testing.T) {
+	srv := startAmbiguousClientsServer(t)
+	defer srv.Close()
+
+	selector := &SelectorMock{}
+	cc := &ClientController{
+		Rport:          api.New(srv.URL, nil),
+		ClientRenderer: &ClientRendererMock{},
+		ClientPicker:   &SelectClientPicker{Selector: selector},
+	}
+
+	err := cc.Client(context.Background(), config.FromValues(map[string]string{config.NoPrompt: "true"}), "", "web*")
+	assert.EqualError(
+		t,
+		err,
+		"client with name \"web*\" is ambiguous, use a more precise name or use the client id.\ndo you mean:\n- web\n- webserver",
+	)
+	assert.Nil(t, selector.items)
+}
+
+func TestTunnelClientPickedWhenNameIsAmbiguous(t *testing.T) {
+	srv := startAmbiguousClientsServer(t)
+	defer srv.Close()
+
+	tc := &TunnelController{
+		Rport:        api.New(srv.URL, nil),
+		ClientPicker: &SelectClientPicker{Selector: &SelectorMock{indexToPick: 0}},
+	}
+
+	clientID, clientName, err := tc.getClientIDAndClientName(
+		context.Background(),
+		config.FromValues(map[string]string{config.ClientNameFlag: "web*"}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1", clientID)
+	assert.Equal(t, "web", clientName)
+}
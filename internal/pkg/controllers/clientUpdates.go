@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	UpdatesRefreshRequested = "refresh requested"
+	UpdatesRefreshFailed    = "failed"
+)
+
+type ClientUpdatesRenderer interface {
+	RenderClientsUpdates(updates []*models.ClientUpdates) error
+	RenderUpdateSummaries(client *models.Client) error
+	RenderUpdatesRefresh(results []*models.ClientOperationResult) error
+}
+
+type ClientUpdatesController struct {
+	Rport                 *api.Rport
+	ClientUpdatesRenderer ClientUpdatesRenderer
+}
+
+// Updates renders the update status of all clients matching the search flags and the update filters
+func (cuc *ClientUpdatesController) Updates(ctx context.Context, params *options.ParameterBag, searchFlags []string) error {
+	filter, err := api.NewFilterFromKVStrings(searchFlags)
+	if err != nil {
+		return err
+	}
+
+	clients, err := cuc.Rport.AllClientsWithFields(ctx, filter, api.ClientUpdatesFields, api.Sort{"name"})
+	if err != nil {
+		return err
+	}
+
+	withUpdates := params.ReadBool(config.UpdatesAvailable, false)
+	withSecurityUpdates := params.ReadBool(config.SecurityUpdatesAvailable, false)
+	withRebootPending := params.ReadBool(config.RebootPending, false)
+
+	updates := make([]*models.ClientUpdates, 0, len(clients))
+	for _, cl := range clients {
+		cu := models.NewClientUpdates(cl)
+		if withUpdates && cu.UpdatesAvailable == 0 {
+			continue
+		}
+		if withSecurityUpdates && cu.SecurityUpdatesAvailable == 0 {
+			continue
+		}
+		if withRebootPending && !cu.RebootPending {
+			continue
+		}
+		updates = append(updates, cu)
+	}
+
+	return cuc.ClientUpdatesRenderer.RenderClientsUpdates(updates)
+}
+
+// UpdateSummaries renders every pending update of a single client identified by its id or name
+func (cuc *ClientUpdatesController) UpdateSummaries(ctx context.Context, id, name string) error {
+	if id == "" && name == "" {
+		return errors.New("no client id or name provided")
+	}
+
+	if id == "" {
+		cl, err := findClientByName(ctx, cuc.Rport, name, nil)
+		if err != nil {
+			return err
+		}
+		id = cl.ID
+	}
+
+	client, err := cuc.Rport.Client(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return cuc.ClientUpdatesRenderer.RenderUpdateSummaries(client)
+}
+
+// Refresh asks the server to refresh the update status of the given clients or of all connected clients
+// matching the name or the search flags
+func (cuc *ClientUpdatesController) Refresh(
+	ctx context.Context,
+	params *options.ParameterBag,
+	ids []string,
+	searchFlags []string,
+) error {
+	clients, err := cuc.readRefreshTargets(ctx, params, ids, searchFlags)
+	if err != nil {
+		return err
+	}
+	if len(clients) == 0 {
+		return errors.New("no connected clients found to refresh the update status on")
+	}
+
+	results := make([]*models.ClientOperationResult, 0, len(clients))
+	failed := 0
+	for _, cl := range clients {
+		result := &models.ClientOperationResult{
+			ClientID:   cl.ID,
+			ClientName: cl.Name,
+			Status:     UpdatesRefreshRequested,
+		}
+		if e := cuc.Rport.RefreshClientUpdatesStatus(ctx, cl.ID); e != nil {
+			result.Status = UpdatesRefreshFailed
+			result.Error = e.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	err = cuc.ClientUpdatesRenderer.RenderUpdatesRefresh(results)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to refresh the update status of %d of %d clients", failed, len(clients))
+	}
+
+	return nil
+}
+
+func (cuc *ClientUpdatesController) readRefreshTargets(
+	ctx context.Context,
+	params *options.ParameterBag,
+	ids []string,
+	searchFlags []string,
+) ([]*models.Client, error) {
+	if len(ids) > 0 {
+		clients := make([]*models.Client, 0, len(ids))
+		for _, id := range ids {
+			clients = append(clients, &models.Client{ID: id})
+		}
+		return clients, nil
+	}
+
+	filter, err := api.NewFilterFromKVStrings(searchFlags)
+	if err != nil {
+		return nil, err
+	}
+	if name := params.ReadString(config.ClientNameFlag, ""); name != "" {
+		filter["name"] = name
+	}
+	if len(filter) == 0 {
+		return nil, errors.New("no client ids, name or search provided")
+	}
+
+	clients, err := cuc.Rport.AllClientsWithFields(ctx, filter, api.Fields{"id", "name", "connection_state"}, api.Sort{"name"})
+	if err != nil {
+		return nil, err
+	}
+
+	connected := make([]*models.Client, 0, len(clients))
+	for _, cl := range clients {
+		if cl.ConnState != models.ClientConnStateConnected {
+			logrus.Debugf("skipping disconnected client %s [%s]", cl.Name, cl.ID)
+			continue
+		}
+		connected = append(connected, cl)
+	}
+
+	return connected, nil
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientUpdatesRendererMock struct {
+	updates       []*models.ClientUpdates
+	client        *models.Client
+	refreshResult []*models.ClientOperationResult
+}
+
+func (curm *ClientUpdatesRendererMock) RenderClientsUpdates(updates []*models.ClientUpdates) error {
+	curm.updates = updates
+	return nil
+}
+
+func (curm *ClientUpdatesRendererMock) RenderUpdateSummaries(client *models.Client) error {
+	curm.client = client
+	return nil
+}
+
+func (curm *ClientUpdatesRendererMock) RenderUpdatesRefresh(results []*models.ClientOperationResult) error {
+	curm.refreshResult = results
+	return nil
+}
+
+func TestClientUpdatesFilters(t *testing.T) {
+	refreshed := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "id,name,updates_status", r.URL.Query().Get("fields[clients]"))
+		assert.Equal(t, "linux", r.URL.Query().Get("filter[os_kernel]"))
+		e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{
+			{ID: "1", Name: "uptodate", UpdatesStatus: &models.UpdatesStatus{Refreshed: refreshed}},
+			{ID: "2", Name: "outdated", UpdatesStatus: &models.UpdatesStatus{Refreshed: refreshed, UpdatesAvailable: 3}},
+			{ID: "3", Name: "insecure", UpdatesStatus: &models.UpdatesStatus{
+				Refreshed:                refreshed,
+				UpdatesAvailable:         2,
+				SecurityUpdatesAvailable: 1,
+				RebootPending:            true,
+			}},
+			{ID: "4", Name: "unknown"},
+		}})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		name          string
+		params        map[string]string
+		expectedNames []string
+	}{
+		{
+			name:          "no filters",
+			params:        map[string]string{},
+			expectedNames: []string{"uptodate", "outdated", "insecure", "unknown"},
+		},
+		{
+			name:          "updates available",
+			params:        map[string]string{config.UpdatesAvailable: "true"},
+			expectedNames: []string{"outdated", "insecure"},
+		},
+		{
+			name:          "security updates and reboot pending",
+			params:        map[string]string{config.SecurityUpdatesAvailable: "true", config.RebootPending: "true"},
+			expectedNames: []string{"insecure"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := &ClientUpdatesRendererMock{}
+			cuc := &ClientUpdatesController{
+				Rport:                 api.New(srv.URL, nil),
+				ClientUpdatesRenderer: renderer,
+			}
+
+			err := cuc.Updates(context.Background(), config.FromValues(tc.params), []string{"os_kernel=linux"})
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(renderer.updates))
+			for _, cu := range renderer.updates {
+				names = append(names, cu.ClientName)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestClientUpdatesRefreshSkipsDisconnectedClients(t *testing.T) {
+	refreshed := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ClientsURL {
+			assert.Equal(t, "web*", r.URL.Query().Get("filter[name]"))
+			e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{
+				{ID: "1", Name: "web1", ConnState: models.ClientConnStateConnected},
+				{ID: "2", Name: "web2", ConnState: models.ClientConnStateDisconnected},
+				{ID: "3", Name: "web3", ConnState: models.ClientConnStateConnected},
+			}})
+			assert.NoError(t, e)
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		clientID := strings.Split(r.URL.Path, "/")[4]
+		refreshed = append(refreshed, clientID)
+		if clientID == "3" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientUpdatesRendererMock{}
+	cuc := &ClientUpdatesController{
+		Rport:                 api.New(srv.URL, nil),
+		ClientUpdatesRenderer: renderer,
+	}
+
+	params := config.FromValues(map[string]string{config.ClientNameFlag: "web*"})
+	err := cuc.Refresh(context.Background(), params, nil, nil)
+	require.EqualError(t, err, "failed to refresh the update status of 1 of 2 clients")
+
+	assert.Equal(t, []string{"1", "3"}, refreshed)
+	require.Len(t, renderer.refreshResult, 2)
+	assert.Equal(t, UpdatesRefreshRequested, renderer.refreshResult[0].Status)
+	assert.Equal(t, UpdatesRefreshFailed, renderer.refreshResult[1].Status)
+	assert.NotEmpty(t, renderer.refreshResult[1].Error)
+}
+
+func TestClientUpdatesRefreshWithoutTargets(t *testing.T) {
+	cuc := &ClientUpdatesController{}
+
+	err := cuc.Refresh(context.Background(), config.FromValues(map[string]string{}), nil, nil)
+	assert.EqualError(t, err, "no client ids, name or search provided")
+}
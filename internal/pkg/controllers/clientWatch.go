@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	DefaultWatchInterval = 10 * time.Second
+	minWatchInterval     = time.Second
+)
+
+// watchedClientFields are the only client fields needed to detect the watched changes
+var watchedClientFields = api.Fields{"id", "name", "address", "connection_state", "disconnected_at"}
+
+type ClientEventRenderer interface {
+	RenderClientEvent(e *models.ClientEvent) error
+}
+
+type ClientWatchController struct {
+	Rport               *api.Rport
+	ClientEventRenderer ClientEventRenderer
+	Now                 func() time.Time
+}
+
+// Watch polls the clients matching the search flags and renders an event for each change until cancelled
+func (cwc *ClientWatchController) Watch(
+	ctx context.Context,
+	params *options.ParameterBag,
+	searchFlags []string,
+	sigs chan os.Signal,
+) error {
+	interval, err := readWatchInterval(params)
+	if err != nil {
+		return err
+	}
+
+	filter, err := api.NewFilterFromKVStrings(searchFlags)
+	if err != nil {
+		return err
+	}
+
+	// the first poll only establishes the state to compare with
+	previous, err := cwc.pollClients(ctx, filter)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("watching %d clients, polling every %s", len(previous), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sigs:
+			return nil
+		case <-ticker.C:
+			current, e := cwc.pollClients(ctx, filter)
+			if e != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				// keep watching, the server might be restarted or the network might be flaky
+				logrus.Warnf("failed to fetch clients: %v", e)
+				continue
+			}
+
+			for _, event := range diffClients(previous, current, cwc.now()) {
+				e = cwc.ClientEventRenderer.RenderClientEvent(event)
+				if e != nil {
+					return e
+				}
+			}
+			previous = current
+		}
+	}
+}
+
+func (cwc *ClientWatchController) now() time.Time {
+	if cwc.Now != nil {
+		return cwc.Now()
+	}
+	return time.Now()
+}
+
+func (cwc *ClientWatchController) pollClients(ctx context.Context, filter api.Filters) (map[string]*models.Client, error) {
+	clients, err := cwc.Rport.AllClientsWithFields(ctx, filter, watchedClientFields, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	clientsByID := make(map[string]*models.Client, len(clients))
+	for _, cl := range clients {
+		clientsByID[cl.ID] = cl
+	}
+
+	return clientsByID, nil
+}
+
+func readWatchInterval(params *options.ParameterBag) (time.Duration, error) {
+	return readPollInterval(params, DefaultWatchInterval)
+}
+
+// readPollInterval reads the --interval flag used by commands polling the server, defaultInterval is used if not set
+func readPollInterval(params *options.ParameterBag, defaultInterval time.Duration) (time.Duration, error) {
+	intervalStr := params.ReadString(config.WatchInterval, "")
+	if intervalStr == "" {
+		return defaultInterval, nil
+	}
+
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --%s value %q: %v", config.WatchInterval, intervalStr, err)
+	}
+	if interval < minWatchInterval {
+		return 0, fmt.Errorf("--%s must be at least %s", config.WatchInterval, minWatchInterval)
+	}
+
+	return interval, nil
+}
+
+// diffClients compares two observations of clients keyed by client id and returns the events sorted by client name
+func diffClients(previous, current map[string]*models.Client, now time.Time) []*models.ClientEvent {
+	events := make([]*models.ClientEvent, 0)
+
+	for id, cl := range current {
+		prev, found := previous[id]
+		if !found {
+			events = append(events, newClientEvent(models.ClientEventNew, cl, now, nil))
+			continue
+		}
+
+		changes := diffWatchedFields(prev, cl)
+		if len(changes) == 0 {
+			continue
+		}
+
+		eventType := models.ClientEventChanged
+		if prev.ConnState != cl.ConnState {
+			switch cl.ConnState {
+			case models.ClientConnStateConnected:
+				eventType = models.ClientEventConnected
+			case models.ClientConnStateDisconnected:
+				eventType = models.ClientEventDisconnected
+			}
+		}
+		events = append(events, newClientEvent(eventType, cl, now, changes))
+	}
+
+	for id, prev := range previous {
+		if _, found := current[id]; !found {
+			events = append(events, newClientEvent(models.ClientEventRemoved, prev, now, nil))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].ClientName != events[j].ClientName {
+			return events[i].ClientName < events[j].ClientName
+		}
+		return events[i].ClientID < events[j].ClientID
+	})
+
+	return events
+}
+
+func diffWatchedFields(prev, cl *models.Client) []models.ClientFieldChange {
+	changes := make([]models.ClientFieldChange, 0)
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{name: "connection_state", from: prev.ConnState, to: cl.ConnState},
+		{name: "disconnected_at", from: prev.DisconnectedAt, to: cl.DisconnectedAt},
+		{name: "address", from: prev.Address, to: cl.Address},
+	}
+	for _, f := range fields {
+		if f.from != f.to {
+			changes = append(changes, models.ClientFieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+
+	return changes
+}
+
+func newClientEvent(eventType string, cl *models.Client, now time.Time, changes []models.ClientFieldChange) *models.ClientEvent {
+	return &models.ClientEvent{
+		Time:           now,
+		Type:           eventType,
+		ClientID:       cl.ID,
+		ClientName:     cl.Name,
+		ConnState:      cl.ConnState,
+		DisconnectedAt: cl.DisconnectedAt,
+		Address:        cl.Address,
+		Changes:        changes,
+	}
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientEventRendererMock struct {
+	mtx    sync.Mutex
+	events []*models.ClientEvent
+}
+
+func (cerm *ClientEventRendererMock) RenderClientEvent(e *models.ClientEvent) error {
+	cerm.mtx.Lock()
+	defer cerm.mtx.Unlock()
+	cerm.events = append(cerm.events, e)
+	return nil
+}
+
+func (cerm *ClientEventRendererMock) Events() []*models.ClientEvent {
+	cerm.mtx.Lock()
+	defer cerm.mtx.Unlock()
+	return cerm.events
+}
+
+func TestDiffClients(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	previous := map[string]*models.Client{
+		"1": {ID: "1", Name: "web1", ConnState: models.ClientConnStateConnected, Address: "1.1.1.1"},
+		"2": {ID: "2", Name: "web2", ConnState: models.ClientConnStateDisconnected, DisconnectedAt: "2022-01-01T00:00:00Z"},
+		"3": {ID: "3", Name: "db1", ConnState: models.ClientConnStateConnected, Address: "3.3.3.3"},
+		"4": {ID: "4", Name: "old", ConnState: models.ClientConnStateConnected},
+		"5": {ID: "5", Name: "same", ConnState: models.ClientConnStateConnected},
+	}
+	current := map[string]*models.Client{
+		"1": {ID: "1", Name: "web1", ConnState: models.ClientConnStateDisconnected, DisconnectedAt: "2022-01-02T03:04:00Z", Address: "1.1.1.1"},
+		"2": {ID: "2", Name: "web2", ConnState: models.ClientConnStateConnected},
+		"3": {ID: "3", Name: "db1", ConnState: models.ClientConnStateConnected, Address: "3.3.3.4"},
+		"5": {ID: "5", Name: "same", ConnState: models.ClientConnStateConnected},
+		"6": {ID: "6", Name: "fresh", ConnState: models.ClientConnStateConnected},
+	}
+
+	events := diffClients(previous, current, now)
+
+	types := make([]string, 0, len(events))
+	for _, e := range events {
+		assert.Equal(t, now, e.Time)
+		types = append(types, e.ClientName+":"+e.Type)
+	}
+	assert.Equal(t, []string{"db1:changed", "fresh:new", "old:removed", "web1:disconnected", "web2:connected"}, types)
+
+	assert.Equal(t, []models.ClientFieldChange{{Field: "address", From: "3.3.3.3", To: "3.3.3.4"}}, events[0].Changes)
+	assert.Equal(t, []models.ClientFieldChange{
+		{Field: "connection_state", From: "connected", To: "disconnected"},
+		{Field: "disconnected_at", From: "", To: "2022-01-02T03:04:00Z"},
+	}, events[3].Changes)
+}
+
+func TestReadWatchInterval(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expected      time.Duration
+		expectedError string
+	}{
+		{value: "", expected: DefaultWatchInterval},
+		{value: "30s", expected: 30 * time.Second},
+		{value: "100ms", expectedError: "--interval must be at least 1s"},
+		{value: "soon", expectedError: `invalid --interval value "soon"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			interval, err := readWatchInterval(config.FromValues(map[string]string{config.WatchInterval: tc.value}))
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, interval)
+		})
+	}
+}
+
+func TestWatchStopsOnSignal(t *testing.T) {
+	var mtx sync.Mutex
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		polls++
+		state := models.ClientConnStateConnected
+		if polls > 1 {
+			state = models.ClientConnStateDisconnected
+		}
+		mtx.Unlock()
+
+		assert.Equal(t, "id,name,address,connection_state,disconnected_at", r.URL.Query().Get("fields[clients]"))
+		assert.Equal(t, "linux", r.URL.Query().Get("filter[os_kernel]"))
+		e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{
+			{ID: "1", Name: "web1", ConnState: state},
+		}})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientEventRendererMock{}
+	cwc := &ClientWatchController{
+		Rport:               api.New(srv.URL, nil),
+		ClientEventRenderer: renderer,
+	}
+
+	sigs := make(chan os.Signal, 1)
+	go func() {
+		for len(renderer.Events()) == 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+		sigs <- os.Interrupt
+	}()
+
+	params := config.FromValues(map[string]string{config.WatchInterval: "1s"})
+	err := cwc.Watch(context.Background(), params, []string{"os_kernel=linux"}, sigs)
+	require.NoError(t, err)
+
+	events := renderer.Events()
+	require.NotEmpty(t, events)
+	assert.Equal(t, models.ClientEventDisconnected, events[0].Type)
+	assert.Equal(t, "web1", events[0].ClientName)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,6 +15,7 @@ import (
 	options "github.com/breathbath/go_utils/v2/pkg/config"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,6 +23,8 @@ import (
 type ClientRendererMock struct {
 	Writer             io.Writer
 	renderDetailsGiven bool
+	fieldsGiven        []string
+	statsGiven         *models.ClientStats
 }
 
 var clientStub = &models.Client{
@@ -56,6 +60,16 @@ func (crm *ClientRendererMock) RenderClients(clients []*models.Client) error {
 	return nil
 }
 
+func (crm *ClientRendererMock) RenderClientsWithFields(clients []*models.Client, fields []string) error {
+	crm.fieldsGiven = fields
+	return crm.RenderClients(clients)
+}
+
+func (crm *ClientRendererMock) RenderClientStats(stats *models.ClientStats) error {
+	crm.statsGiven = stats
+	return nil
+}
+
 func (crm *ClientRendererMock) RenderClient(client *models.Client, renderDetails bool) error {
 	crm.renderDetailsGiven = renderDetails
 
@@ -171,6 +185,30 @@ func TestClientSearch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClientsControllerAllPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0", r.URL.Query().Get("page[offset]"))
+		assert.Equal(t, strconv.Itoa(api.ClientsLimitMax), r.URL.Query().Get("page[limit]"))
+		e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{clientStub}})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	clController := ClientController{
+		Rport:          api.New(srv.URL, nil),
+		ClientRenderer: &ClientRendererMock{Writer: &buf},
+	}
+
+	params := options.New(options.NewMapValuesProvider(map[string]interface{}{
+		api.PaginationAll:   true,
+		api.PaginationLimit: 10,
+	}))
+	err := clController.Clients(context.Background(), params, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"id":"123"`)
+}
+
 func TestInvalidInputForClients(t *testing.T) {
 	clController := ClientController{}
 
@@ -202,3 +240,78 @@ func startClientServer() *httptest.Server {
 
 	return srv
 }
+
+func TestClientsControllerWithFieldsAndSort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "id,cpu_model_name,mem_total", r.URL.Query().Get("fields[clients]"))
+		assert.Equal(t, []string{"os_family", "-name"}, r.URL.Query()["sort"])
+		e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{clientStub}})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	renderMock := &ClientRendererMock{Writer: &buf}
+	clController := ClientController{
+		Rport:          api.New(srv.URL, nil),
+		ClientRenderer: renderMock,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientFields: "id, cpu_model_name,mem_total",
+		config.ClientSort:   "os_family,-name",
+	})
+	err := clController.Clients(context.Background(), params, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "cpu_model_name", "mem_total"}, renderMock.fieldsGiven)
+}
+
+func TestClientsControllerWithUnknownField(t *testing.T) {
+	clController := ClientController{}
+
+	params := config.FromValues(map[string]string{config.ClientSort: "-cpu"})
+	err := clController.Clients(context.Background(), params, []string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown client field "cpu", supported fields are: id, name,`)
+}
+
+func TestClientStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "id,os_family,version", r.URL.Query().Get("fields[clients]"))
+		assert.Equal(t, "linux", r.URL.Query().Get("filter[os_kernel]"))
+		e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{
+			{ID: "1", OsFamily: "debian", Version: "0.9.0"},
+			{ID: "2", OsFamily: "ubuntu", Version: "0.9.0"},
+			{ID: "3", OsFamily: "debian", Version: "0.9.0"},
+			{ID: "4", OsFamily: "debian", Version: "0.8.0"},
+		}})
+		assert.NoError(t, e)
+	}))
+	defer srv.Close()
+
+	renderer := &ClientRendererMock{}
+	cc := &ClientController{
+		Rport:          api.New(srv.URL, nil),
+		ClientRenderer: renderer,
+	}
+
+	params := config.FromValues(map[string]string{config.ClientStatsBy: "os_family, version"})
+	err := cc.Stats(context.Background(), params, []string{"os_kernel=linux"})
+	require.NoError(t, err)
+
+	require.NotNil(t, renderer.statsGiven)
+	assert.Equal(t, 4, renderer.statsGiven.Total)
+	assert.Equal(t, []*models.ClientStatsGroup{
+		{Fields: map[string]string{"os_family": "debian", "version": "0.9.0"}, Count: 2, Percent: 50},
+		{Fields: map[string]string{"os_family": "debian", "version": "0.8.0"}, Count: 1, Percent: 25},
+		{Fields: map[string]string{"os_family": "ubuntu", "version": "0.9.0"}, Count: 1, Percent: 25},
+	}, renderer.statsGiven.Groups)
+}
+
+func TestClientStatsWithUnknownField(t *testing.T) {
+	cc := &ClientController{}
+
+	err := cc.Stats(context.Background(), config.FromValues(map[string]string{config.ClientStatsBy: "family"}), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown client field "family"`)
+}
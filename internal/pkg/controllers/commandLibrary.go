@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+type StoredCommandRenderer interface {
+	RenderStoredCommands(commands []*models.StoredCommand) error
+	RenderStoredCommand(sc *models.StoredCommand) error
+	RenderDelete(s output.KvProvider) error
+}
+
+type CommandLibraryController struct {
+	Rport                 *api.Rport
+	StoredCommandRenderer StoredCommandRenderer
+}
+
+func (clc *CommandLibraryController) List(ctx context.Context, params *options.ParameterBag) error {
+	pagination := api.NewPaginationFromParams(params)
+	resp, err := clc.Rport.StoredCommands(ctx, pagination, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.Truncated(pagination) {
+		logrus.Warnf(
+			"showing %d of %d stored commands, use --%s and --%s to see more",
+			len(resp.Data),
+			resp.Meta.Count,
+			api.PaginationLimit,
+			api.PaginationOffset,
+		)
+	}
+
+	return clc.StoredCommandRenderer.RenderStoredCommands(resp.Data)
+}
+
+func (clc *CommandLibraryController) Get(ctx context.Context, ref string) error {
+	sc, err := findStoredCommand(ctx, clc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	return clc.StoredCommandRenderer.RenderStoredCommand(sc)
+}
+
+func (clc *CommandLibraryController) Create(ctx context.Context, params *options.ParameterBag, name string) error {
+	if name == "" {
+		return errors.New("no name provided")
+	}
+	cmd := params.ReadString(config.Command, "")
+	if cmd == "" {
+		return fmt.Errorf("no command provided, use --%s", config.Command)
+	}
+
+	sc, err := clc.Rport.CreateStoredCommand(ctx, &models.StoredCommand{
+		Name:        name,
+		Cmd:         cmd,
+		Interpreter: params.ReadString(config.Interpreter, ""),
+	})
+	if err != nil {
+		return err
+	}
+
+	return clc.StoredCommandRenderer.RenderStoredCommand(sc)
+}
+
+// Update changes only the name, command or interpreter given by the flags and keeps the other values
+func (clc *CommandLibraryController) Update(ctx context.Context, params *options.ParameterBag, ref string) error {
+	sc, err := findStoredCommand(ctx, clc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if name := params.ReadString(config.NewName, ""); name != "" {
+		sc.Name = name
+		changed = true
+	}
+	if cmd := params.ReadString(config.Command, ""); cmd != "" {
+		sc.Cmd = cmd
+		changed = true
+	}
+	if interpreter := params.ReadString(config.Interpreter, ""); interpreter != "" {
+		sc.Interpreter = interpreter
+		changed = true
+	}
+	if !changed {
+		return fmt.Errorf("nothing to update, use --%s, --%s or --%s", config.NewName, config.Command, config.Interpreter)
+	}
+
+	sc, err = clc.Rport.UpdateStoredCommand(ctx, sc.ID, sc)
+	if err != nil {
+		return err
+	}
+
+	return clc.StoredCommandRenderer.RenderStoredCommand(sc)
+}
+
+func (clc *CommandLibraryController) Delete(ctx context.Context, ref string) error {
+	sc, err := findStoredCommand(ctx, clc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	err = clc.Rport.DeleteStoredCommand(ctx, sc.ID)
+	if err != nil {
+		return err
+	}
+
+	return clc.StoredCommandRenderer.RenderDelete(&models.OperationStatus{Status: "Stored command successfully deleted"})
+}
+
+// findStoredCommand finds a stored command by its exact name or, if no command has this name, by its id
+func findStoredCommand(ctx context.Context, rport *api.Rport, ref string) (*models.StoredCommand, error) {
+	if ref == "" {
+		return nil, errors.New("no stored command name or id provided")
+	}
+
+	resp, err := rport.StoredCommands(ctx, api.NewPaginationWithLimit(api.ClientsLimitMax), api.NewFilters("name", ref))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, 1)
+	var found *models.StoredCommand
+	for _, sc := range resp.Data {
+		if sc.Name == ref {
+			ids = append(ids, sc.ID)
+			found = sc
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		sc, err := rport.StoredCommand(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("unknown stored command %q: %v", ref, err)
+		}
+		return sc, nil
+	case 1:
+		return found, nil
+	default:
+		return nil, ambiguousLibraryNameError("commands", ref, ids)
+	}
+}
+
+func ambiguousLibraryNameError(itemType, name string, ids []string) error {
+	return fmt.Errorf("%d stored %s are named %q, use one of the ids instead: %s", len(ids), itemType, name, strings.Join(ids, ", "))
+}
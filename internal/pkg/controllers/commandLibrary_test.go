@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+type StoredCommandRendererMock struct {
+	commands     []*models.StoredCommand
+	command      *models.StoredCommand
+	deleteStatus output.KvProvider
+}
+
+func (scrm *StoredCommandRendererMock) RenderStoredCommands(commands []*models.StoredCommand) error {
+	scrm.commands = commands
+	return nil
+}
+
+func (scrm *StoredCommandRendererMock) RenderStoredCommand(sc *models.StoredCommand) error {
+	scrm.command = sc
+	return nil
+}
+
+func (scrm *StoredCommandRendererMock) RenderDelete(s output.KvProvider) error {
+	scrm.deleteStatus = s
+	return nil
+}
+
+// storedCommandsHandler serves the given stored commands filtered by name like the server does
+func storedCommandsHandler(t *testing.T, commands []*models.StoredCommand) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.StoredCommandsURL, r.URL.Path)
+		matching := make([]*models.StoredCommand, 0)
+		for _, sc := range commands {
+			if sc.Name == r.URL.Query().Get("filter[name]") {
+				matching = append(matching, sc)
+			}
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.StoredCommandsResponse{Data: matching}))
+	}
+}
+
+func TestUpdateStoredCommandKeepsUnchangedValues(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(api.StoredCommandsURL, storedCommandsHandler(t, []*models.StoredCommand{
+		{ID: "sc1", Name: "disk-usage", Cmd: "df -h", Interpreter: "bash"},
+	}))
+	mux.HandleFunc(api.StoredCommandsURL+"/sc1", func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"disk-usage","cmd":"df -h /","interpreter":"bash"}`, string(body))
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.StoredCommandResponse{
+			Data: &models.StoredCommand{ID: "sc1", Name: "disk-usage", Cmd: "df -h /", Interpreter: "bash"},
+		}))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	renderer := &StoredCommandRendererMock{}
+	clc := &CommandLibraryController{
+		Rport:                 api.New(srv.URL, nil),
+		StoredCommandRenderer: renderer,
+	}
+
+	err := clc.Update(context.Background(), config.FromValues(map[string]string{config.Command: "df -h /"}), "disk-usage")
+	require.NoError(t, err)
+	assert.Equal(t, "df -h /", renderer.command.Cmd)
+
+	err = clc.Update(context.Background(), config.FromValues(map[string]string{}), "disk-usage")
+	assert.EqualError(t, err, "nothing to update, use --new-name, --command or --interpreter")
+}
+
+func TestCreateStoredCommandWithoutCommand(t *testing.T) {
+	clc := &CommandLibraryController{}
+
+	err := clc.Create(context.Background(), config.FromValues(map[string]string{}), "disk-usage")
+	assert.EqualError(t, err, "no command provided, use --command")
+}
+
+func TestFindStoredCommand(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(api.StoredCommandsURL, storedCommandsHandler(t, []*models.StoredCommand{
+		{ID: "sc1", Name: "uptime", Cmd: "uptime"},
+		{ID: "sc2", Name: "reboot", Cmd: "reboot"},
+		{ID: "sc3", Name: "reboot", Cmd: "shutdown -r now"},
+	}))
+	mux.HandleFunc(api.StoredCommandsURL+"/sc2", func(rw http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.StoredCommandResponse{
+			Data: &models.StoredCommand{ID: "sc2", Name: "reboot", Cmd: "reboot"},
+		}))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	rport := api.New(srv.URL, nil)
+
+	sc, err := findStoredCommand(context.Background(), rport, "uptime")
+	require.NoError(t, err)
+	assert.Equal(t, "sc1", sc.ID)
+
+	sc, err = findStoredCommand(context.Background(), rport, "sc2")
+	require.NoError(t, err)
+	assert.Equal(t, "reboot", sc.Cmd)
+
+	_, err = findStoredCommand(context.Background(), rport, "reboot")
+	assert.EqualError(t, err, `2 stored commands are named "reboot", use one of the ids instead: sc2, sc3`)
+
+	_, err = findStoredCommand(context.Background(), rport, "unknown")
+	assert.Error(t, err)
+}
+
+func TestCommandExecutionFromLibrary(t *testing.T) {
+	srv := httptest.NewServer(storedCommandsHandler(t, []*models.StoredCommand{
+		{ID: "sc1", Name: "services", Cmd: "Get-Service", Interpreter: "powershell"},
+	}))
+	defer srv.Close()
+
+	rw := makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "1235"))
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{
+			ReadWriter:  rw,
+			JobRenderer: &JobRendererMock{},
+			Rport:       api.New(srv.URL, nil),
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:   "1235",
+		config.FromLibrary: "services",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, rw.writtenItems, 1)
+	assert.JSONEq(
+		t,
+		`{"client_ids":["1235"],"is_sudo":false,"execute_concurrently":false,"abort_on_error":false,"timeout_sec":30,"command":"Get-Service","script":"","cwd":"","interpreter":"powershell"}`,
+		rw.writtenItems[0],
+	)
+
+	params = config.FromValues(map[string]string{
+		config.ClientIDs:   "1235",
+		config.Command:     "uptime",
+		config.FromLibrary: "services",
+	})
+	err = cc.Start(context.Background(), params, nil, nil)
+	assert.EqualError(t, err, "--command can't be used with --from-library")
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
 	options "github.com/breathbath/go_utils/v2/pkg/config"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
@@ -15,5 +16,23 @@ func (cc *CommandsController) Start(ctx context.Context,
 	params *options.ParameterBag,
 	promptReader config.PromptReader,
 	hostInfo *config.HostInfo) error {
-	return cc.execute(ctx, params, "", params.ReadString(config.Interpreter, ""), promptReader, hostInfo)
+	command := params.ReadString(config.Command, "")
+	interpreter := params.ReadString(config.Interpreter, "")
+
+	if ref := params.ReadString(config.FromLibrary, ""); ref != "" {
+		if command != "" {
+			return fmt.Errorf("--%s can't be used with --%s", config.Command, config.FromLibrary)
+		}
+		sc, err := findStoredCommand(ctx, cc.Rport, ref)
+		if err != nil {
+			return err
+		}
+		command = sc.Cmd
+		// an interpreter given explicitly wins over the stored one
+		if interpreter == "" {
+			interpreter = sc.Interpreter
+		}
+	}
+
+	return cc.execute(ctx, params, command, "", interpreter, promptReader, hostInfo)
 }
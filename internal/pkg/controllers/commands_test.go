@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
@@ -20,7 +23,7 @@ func TestCommandExecutionByClientIDsSuccess(t *testing.T) {
 		Jid:         "123",
 		Status:      "done",
 		FinishedAt:  time.Now(),
-		ClientID:    "123",
+		ClientID:    "1235",
 		Command:     "ls",
 		Interpreter: "sh",
 		Pid:         12,
@@ -83,6 +86,7 @@ func TestCommandExecutionByClientIDsSuccess(t *testing.T) {
 	actualJobRenderResult, err := json.Marshal(jr.jobToRender)
 	assert.NoError(t, err)
 	assert.Equal(t, string(jobRespBytes), string(actualJobRenderResult))
+	assert.True(t, jr.flushed)
 	assert.True(t, rw.isClosed)
 }
 
@@ -151,3 +155,89 @@ func TestCommandExecutionWithInvalidResponse(t *testing.T) {
 	}
 	assert.Contains(t, err.Error(), "some error, code: 500, details: some error detail")
 }
+
+func TestCommandExecutionDetached(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, api.MultiJobsURL, r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(
+			t,
+			`{"client_ids":["1235"],"is_sudo":false,"execute_concurrently":false,"abort_on_error":false,"timeout_sec":30,"command":"cmd","script":"","cwd":"","interpreter":""}`,
+			string(body),
+		)
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.JobStartedResponse{Data: &models.JobStarted{Jid: "multi-1"}}))
+	}))
+	defer srv.Close()
+
+	jr := &JobRendererMock{}
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{
+			JobRenderer: jr,
+			Rport:       api.New(srv.URL, nil),
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "1235",
+		config.Command:   "cmd",
+		config.Detach:    "1",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &models.JobStarted{Jid: "multi-1"}, jr.jobStartedToRender)
+	assert.Nil(t, jr.jobToRender)
+}
+
+func TestCommandExecutionDetachedWithExecLog(t *testing.T) {
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:    "1235",
+		config.Command:      "cmd",
+		config.Detach:       "1",
+		config.WriteExecLog: "run.yaml",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assert.EqualError(t, err, "--write-execlog can't be used with --detach, use it with 'job wait' instead")
+}
+
+func TestCommandExecutionAggregateWithIncompatibleParams(t *testing.T) {
+	testCases := []struct {
+		name          string
+		param         string
+		expectedError string
+	}{
+		{
+			name:          "detach",
+			param:         config.Detach,
+			expectedError: "--aggregate can't be used with --detach, use it with 'job wait' instead",
+		},
+		{
+			name:          "full output",
+			param:         config.IsFullOutput,
+			expectedError: "--aggregate can't be used with --full-command-response",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := &CommandsController{
+				ExecutionHelper: &ExecutionHelper{},
+			}
+
+			params := config.FromValues(map[string]string{
+				config.ClientIDs: "1235",
+				config.Command:   "cmd",
+				config.Aggregate: "1",
+				tc.param:         "1",
+			})
+			err := cc.Start(context.Background(), params, nil, nil)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,11 +18,16 @@ import (
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	waitingMsg = "waiting for the command to finish"
+
+	// maxVariantConnections limits the variants of a batch executed at the same time with --conc,
+	// each of them is sent and read over its own connection to the server
+	maxVariantConnections = 10
 )
 
 type CliReader interface {
@@ -43,90 +49,541 @@ type Spinner interface {
 
 type JobRenderer interface {
 	RenderJob(j *models.Job) error
+	RenderJobStarted(js *models.JobStarted) error
+	// Flush renders the jobs held back by the renderer, e.g. to show the clients with identical output as a group
+	Flush() error
+	RenderSummary(es *models.ExecutionSummary) error
+	RenderCommandVariants(variants []*models.CommandVariant) error
 }
 
 type ExecutionHelper struct {
 	JobRenderer JobRenderer
 	ReadWriter  ReadWriter
-	Rport       *api.Rport
+	// NewReadWriter connects again to send each further command, e.g. of the next batch, of another variant
+	// or to the clients it has failed on, the server closes the connection when all jobs have finished
+	NewReadWriter func(ctx context.Context) (ReadWriter, error)
+	Rport         *api.Rport
 
 	ExecutedAt       time.Time
 	ExecutionResults []*models.Job
+
+	outputDir *OutputDir
+	// clientNames are the names of the targeted clients resolved by targeting them, by id
+	clientNames map[string]string
+	// mu guards the results, the output dir, the renderer and the connecting while variants are read concurrently
+	mu sync.Mutex
+}
+
+// connection is the connection to the server a command variant is sent and read over, the variants of a batch
+// have a connection each to be read concurrently
+type connection struct {
+	rw      ReadWriter
+	attempt int
+	// targets are the clients of the current attempt and jobs their finished jobs
+	targets []string
+	jobs    []*models.Job
+}
+
+// failedClientIDs returns the clients the current attempt has failed on
+func (c *connection) failedClientIDs() []string {
+	return failedClientIDs(c.targets, c.jobs)
+}
+
+func (c *connection) close() {
+	if c.rw != nil {
+		io2.CloseResourceSecure("read writer", c.rw)
+		c.rw = nil
+	}
+}
+
+// variantResult tells how reading the jobs of a command variant ended
+type variantResult struct {
+	interrupted bool
+	err         error
 }
 
+// executeOptions are the parameters telling how the results of an execution are handled
+type executeOptions struct {
+	failThreshold *utils.Threshold
+	reports       []*Report
+	retry         *RetryPolicy
+	rollout       *Rollout
+	template      bool
+	dryRun        bool
+}
+
+// execute runs either the command or, if the script payload is set, the base64 encoded script on the targeted clients
 func (eh *ExecutionHelper) execute(ctx context.Context,
 	params *options.ParameterBag,
-	scriptPayload, interpreter string,
+	command, scriptPayload, interpreter string,
 	promptReader config.PromptReader,
 	hostInfo *config.HostInfo) (err error) {
-	if eh.ReadWriter != nil {
-		defer io2.CloseResourceSecure("read writer", eh.ReadWriter)
-	}
+	// the read writer is handed over to the first command sent, it's closed here if nothing was sent
+	defer func() {
+		if eh.ReadWriter != nil {
+			io2.CloseResourceSecure("read writer", eh.ReadWriter)
+		}
+	}()
 
+	detach := params.ReadBool(config.Detach, false)
+	outputDir := params.ReadString(config.OutputDir, "")
+	opts, err := readExecuteOptions(params)
+	if err != nil {
+		return err
+	}
 	var el *ExecutionLog
-	clientIDs := ""
+	if !opts.dryRun {
+		el, err = prepareExecLog(params, promptReader, hostInfo)
+		if err != nil {
+			return err
+		}
+	}
 
-	execLogRequested, logFilename := config.ExecLogRequested(params)
-	if execLogRequested {
-		el = NewExecLog(params, logFilename, promptReader, hostInfo)
-		if el.ExistingLog() {
-			// user response saved in the exec log
-			_, err = el.ConfirmOverwrite()
+	clientIDs, clientNames, err := eh.readClientIDs(ctx, params, promptReader)
+	if err != nil {
+		return err
+	}
+	if clientIDs == "" {
+		return &ExitError{Code: ExitCodeNoTargets, Msg: "no clients match your targeting criteria"}
+	}
+
+	// initialize ready for new run
+	eh.ExecutionResults = make([]*models.Job, 0)
+	eh.ExecutedAt = time.Now()
+	eh.clientNames = clientNames
+
+	wsCmd := eh.buildExecInput(params, clientIDs, command, scriptPayload, interpreter)
+	variants, err := eh.renderVariants(ctx, wsCmd, opts)
+	if err != nil {
+		return err
+	}
+	addVariantClientNames(eh.clientNames, variants)
+	if opts.dryRun {
+		return eh.JobRenderer.RenderCommandVariants(variants)
+	}
+	if detach {
+		return eh.startDetached(ctx, wsCmd)
+	}
+
+	eh.outputDir = nil
+	if outputDir != "" {
+		eh.outputDir, err = NewOutputDir(outputDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := eh.runBatches(ctx, wsCmd, variants, opts)
+	return eh.completeExecution(el, opts, res, err)
+}
+
+// completeExecution completes reading even if running failed, writes the results and turns them into the exit code
+func (eh *ExecutionHelper) completeExecution(el *ExecutionLog, opts *executeOptions, res *rolloutResult, runErr error) error {
+	completeErr := eh.completeReading(res.executedClientIDs)
+	if runErr != nil {
+		return runErr
+	}
+	if completeErr != nil {
+		return completeErr
+	}
+
+	err := eh.writeResults(el, opts.reports)
+	if err != nil {
+		return err
+	}
+
+	if res.stopReason != "" && !res.interrupted {
+		return &ExitError{
+			Code: ExitCodeRolloutStopped,
+			Msg:  fmt.Sprintf("rollout stopped, %s, not executed on the remaining %d clients", res.stopReason, res.skippedClients),
+		}
+	}
+
+	return checkJobResults(res.executedClientIDs, finalJobs(eh.ExecutionResults), opts.failThreshold, res.interrupted)
+}
+
+// run executes the commands of a batch, one per variant and each over its own connection. Like the clients of a
+// single command, the variants are executed one after another unless --conc is given, then up to
+// maxVariantConnections variants are read at the same time and a variant which is interrupted or fails stops the others.
+func (eh *ExecutionHelper) run(ctx context.Context, cmds []*models.WsScriptCommand, retry *RetryPolicy) (interrupted bool, err error) {
+	if len(cmds) > 1 && cmds[0].ExecuteConcurrently {
+		return eh.runConcurrently(ctx, cmds, retry)
+	}
+
+	for i, wsCmd := range cmds {
+		conn := &connection{}
+		interrupted, err = eh.sendAndRunVariant(ctx, conn, wsCmd, retry)
+		conn.close()
+		if interrupted || err != nil {
+			return interrupted, err
+		}
+
+		if wsCmd.AbortOnError && len(conn.failedClientIDs()) > 0 && i < len(cmds)-1 {
+			logrus.Infof("--%s: the execution has failed, the remaining %d variants are not executed", config.AbortOnError, len(cmds)-i-1)
+			return false, nil
+		}
+	}
+
+	return false, nil
+}
+
+// runConcurrently sends the command of the next variant as soon as less than maxVariantConnections are read,
+// the variant which is interrupted or fails first tells the result
+func (eh *ExecutionHelper) runConcurrently(
+	ctx context.Context,
+	cmds []*models.WsScriptCommand,
+	retry *RetryPolicy,
+) (interrupted bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan variantResult, len(cmds))
+	var first *variantResult
+	receive := func() {
+		res := <-results
+		if first == nil && (res.interrupted || res.err != nil) {
+			first = &res
+		}
+	}
+
+	running := 0
+	for _, wsCmd := range cmds {
+		if running == maxVariantConnections {
+			receive()
+			running--
+		}
+		if first != nil {
+			break
+		}
+
+		conn := &connection{}
+		err = eh.send(ctx, conn, wsCmd, retry.attempt(0))
+		if err != nil {
+			conn.close()
+			first = &variantResult{err: err}
+			cancel()
+			break
+		}
+
+		running++
+		go func(conn *connection, wsCmd *models.WsScriptCommand) {
+			var res variantResult
+			res.interrupted, res.err = eh.runVariant(ctx, conn, wsCmd, retry)
+			conn.close()
+			// the result is sent before the other variants are stopped to come before their cancellation errors
+			results <- res
+			if res.interrupted || res.err != nil {
+				cancel()
+			}
+		}(conn, wsCmd)
+	}
+
+	for ; running > 0; running-- {
+		receive()
+	}
+	if first != nil {
+		return first.interrupted, first.err
+	}
+
+	return false, nil
+}
+
+func (eh *ExecutionHelper) sendAndRunVariant(
+	ctx context.Context,
+	conn *connection,
+	wsCmd *models.WsScriptCommand,
+	retry *RetryPolicy,
+) (interrupted bool, err error) {
+	err = eh.send(ctx, conn, wsCmd, retry.attempt(0))
+	if err != nil {
+		return false, err
+	}
+
+	return eh.runVariant(ctx, conn, wsCmd, retry)
+}
+
+// runVariant reads the jobs of the command sent over the connection, then the command is sent again to the clients
+// it has failed on as long as retries are left, each retry over a new connection
+func (eh *ExecutionHelper) runVariant(
+	ctx context.Context,
+	conn *connection,
+	wsCmd *models.WsScriptCommand,
+	retry *RetryPolicy,
+) (interrupted bool, err error) {
+	for retries := 0; ; retries++ {
+		if retries > 0 {
+			err = eh.send(ctx, conn, wsCmd, retry.attempt(retries))
 			if err != nil {
-				return err
+				return false, err
 			}
 		}
+
+		interrupted, err = eh.startReading(ctx, conn)
+		if interrupted || err != nil || retries == retry.Retries {
+			return interrupted, err
+		}
+
+		clientIDs := conn.failedClientIDs()
+		if len(clientIDs) == 0 {
+			return false, nil
+		}
+
+		// the groups of an attempt are rendered before the next attempt starts
+		err = eh.flush()
+		if err != nil {
+			return false, err
+		}
+
+		delay := retry.DelayBefore(retries + 1)
+		logrus.Infof("failed on %d clients, retry %d of %d in %s", len(clientIDs), retries+1, retry.Retries, delay)
+		interrupted, err = waitInterruptibly(ctx, delay)
+		if interrupted || err != nil {
+			return interrupted, err
+		}
+
+		retryCmd := *wsCmd
+		retryCmd.ClientIDs = clientIDs
+		retryCmd.GroupIDs = nil
+		wsCmd = &retryCmd
 	}
+}
 
-	hasSourceExecLog, sourceLogFilename := config.SourceExecLog(params)
-	if hasSourceExecLog {
-		// get failed clientIDs from previous exec log
-		sl := NewExecLog(params, sourceLogFilename, promptReader, nil)
-		clientIDs, err = sl.GetAndConfirmFailedClientIDs()
+// send sends the command over a new connection replacing the previous one of the variant,
+// the attempt is stored with the jobs read over it
+func (eh *ExecutionHelper) send(ctx context.Context, conn *connection, wsCmd *models.WsScriptCommand, attempt int) error {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	conn.close()
+	rw, err := eh.connect(ctx)
+	if err != nil {
+		return err
+	}
+	conn.rw = rw
+	conn.attempt = attempt
+	conn.targets = wsCmd.ClientIDs
+	conn.jobs = nil
+
+	return sendCommand(rw, wsCmd)
+}
+
+// connect returns the initial connection for the first command, each further command gets a new connection
+func (eh *ExecutionHelper) connect(ctx context.Context) (ReadWriter, error) {
+	if eh.ReadWriter != nil {
+		rw := eh.ReadWriter
+		eh.ReadWriter = nil
+		return rw, nil
+	}
+	if eh.NewReadWriter == nil {
+		return nil, errors.New("the command can't be sent without a new connection to the server")
+	}
+
+	return eh.NewReadWriter(ctx)
+}
+
+func (eh *ExecutionHelper) flush() error {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	return eh.JobRenderer.Flush()
+}
+
+func readExecuteOptions(params *options.ParameterBag) (*executeOptions, error) {
+	err := checkExecuteParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &executeOptions{}
+	opts.failThreshold, err = readFailThreshold(params)
+	if err != nil {
+		return nil, err
+	}
+	opts.reports, err = ParseReports(config.ReadReports(params))
+	if err != nil {
+		return nil, err
+	}
+	opts.retry, err = readRetryPolicy(params)
+	if err != nil {
+		return nil, err
+	}
+	opts.rollout, err = readRollout(params)
+	if err != nil {
+		return nil, err
+	}
+	opts.template = params.ReadBool(config.Template, false)
+	opts.dryRun = params.ReadBool(config.DryRun, false)
+
+	return opts, nil
+}
+
+// prepareExecLog returns the --write-execlog file to write the results to, an existing file has to be confirmed
+// to be overwritten, nil is returned if no exec log is requested
+func prepareExecLog(
+	params *options.ParameterBag,
+	promptReader config.PromptReader,
+	hostInfo *config.HostInfo,
+) (*ExecutionLog, error) {
+	execLogRequested, logFilename := config.ExecLogRequested(params)
+	if !execLogRequested {
+		return nil, nil
+	}
+
+	el := NewExecLog(params, logFilename, promptReader, hostInfo)
+	if el.ExistingLog() {
+		// user response saved in the exec log
+		_, err := el.ConfirmOverwrite()
 		if err != nil {
-			return err
+			return nil, err
 		}
-	} else {
-		clientIDs, err = eh.getClientIDsFromParams(ctx, params)
+	}
+
+	return el, nil
+}
+
+// writeResults writes the finished jobs to the exec log and the reports
+func (eh *ExecutionHelper) writeResults(el *ExecutionLog, reports []*Report) error {
+	if el != nil && el.ShouldWriteLog() {
+		err := el.WriteExecLog(eh.ExecutedAt, eh.ExecutionResults)
 		if err != nil {
 			return err
 		}
 	}
-	if clientIDs == "" {
-		logrus.Fatalf("no clients match your targeting criterea")
-		return nil
+
+	return WriteReports(reports, &ExecutionLogInfo{ExecutedAt: eh.ExecutedAt, Jobs: eh.ExecutionResults})
+}
+
+// readClientIDs returns the failed clients of the --read-execlog file or the clients given by the targeting params
+// and the names of the clients if they are known
+func (eh *ExecutionHelper) readClientIDs(
+	ctx context.Context,
+	params *options.ParameterBag,
+	promptReader config.PromptReader,
+) (clientIDs string, clientNames map[string]string, err error) {
+	hasSourceExecLog, sourceLogFilename := config.SourceExecLog(params)
+	if hasSourceExecLog {
+		sl := NewExecLog(params, sourceLogFilename, promptReader, nil)
+		return sl.GetAndConfirmFailedClientIDs()
 	}
 
-	// initialize ready for new run
-	eh.ExecutionResults = make([]*models.Job, 0)
-	eh.ExecutedAt = time.Now()
+	return getClientIDsFromParams(ctx, eh.Rport, params)
+}
 
-	wsCmd := eh.buildExecInput(params, clientIDs, scriptPayload, interpreter)
-	err = eh.sendCommand(wsCmd)
+func checkExecuteParams(params *options.ParameterBag) error {
+	if params.ReadBool(config.Detach, false) {
+		if execLogRequested, _ := config.ExecLogRequested(params); execLogRequested {
+			return fmt.Errorf("--%s can't be used with --%s, use it with 'job wait' instead", config.WriteExecLog, config.Detach)
+		}
+		if params.ReadString(config.OutputDir, "") != "" {
+			return fmt.Errorf("--%s can't be used with --%s", config.OutputDir, config.Detach)
+		}
+		if params.ReadInt(config.Retries, 0) > 0 {
+			return fmt.Errorf("--%s can't be used with --%s", config.Retries, config.Detach)
+		}
+		if params.ReadString(config.BatchSize, "") != "" || params.ReadInt(config.Canary, 0) > 0 {
+			return fmt.Errorf("--%s and --%s can't be used with --%s", config.BatchSize, config.Canary, config.Detach)
+		}
+		if len(config.ReadReports(params)) > 0 {
+			return fmt.Errorf(
+				"--%s can't be used with --%s, use --%s with 'job wait' and 'execlog convert' instead",
+				config.Report,
+				config.Detach,
+				config.WriteExecLog,
+			)
+		}
+	}
+
+	err := checkTemplateParams(params)
 	if err != nil {
 		return err
 	}
 
-	err = eh.startReading(ctx)
+	return checkAggregateParams(params)
+}
+
+func readFailThreshold(params *options.ParameterBag) (*utils.Threshold, error) {
+	failThreshold, err := utils.ParseThreshold(params.ReadString(config.FailThreshold, ""))
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("--%s: %v", config.FailThreshold, err)
 	}
 
-	if execLogRequested {
-		if el.ShouldWriteLog() {
-			err = el.WriteExecLog(eh.ExecutedAt, eh.ExecutionResults)
-			if err != nil {
-				return err
-			}
+	return failThreshold, nil
+}
+
+// checkJobResults turns an interrupted execution and more failures than tolerated by the threshold
+// into an error with a distinct exit code, see failedClientIDs for what counts as failure
+func checkJobResults(targets []string, jobs []*models.Job, failThreshold *utils.Threshold, interrupted bool) error {
+	if interrupted {
+		failed, finished := countFailures(nil, jobs)
+		return &ExitError{
+			Code: ExitCodeInterrupted,
+			Msg:  fmt.Sprintf("interrupted, %d jobs finished until then, %d of them failed", finished, failed),
 		}
 	}
 
+	failed, total := countFailures(targets, jobs)
+	switch {
+	case !failThreshold.Exceeded(failed, total):
+		return nil
+	case failed == total:
+		return &ExitError{Code: ExitCodeAllFailed, Msg: fmt.Sprintf("failed on all %d clients", total)}
+	default:
+		return &ExitError{Code: ExitCodeSomeFailed, Msg: fmt.Sprintf("failed on %d of %d clients", failed, total)}
+	}
+}
+
+// countFailures returns how many of the clients failed as defined by failedClientIDs,
+// total is the number of targeted clients and clients with a job
+func countFailures(targets []string, jobs []*models.Job) (failed, total int) {
+	return len(failedClientIDs(targets, jobs)), len(jobs) + len(unreportedClientIDs(targets, jobs))
+}
+
+// completeReading renders the jobs held back by the renderer and the summary and writes the index of the output dir,
+// it's called even if reading was interrupted to keep the results of the jobs finished so far
+func (eh *ExecutionHelper) completeReading(clientIDs []string) error {
+	err := completeRendering(eh.JobRenderer, clientIDs, eh.clientNames, finalJobs(eh.ExecutionResults))
+	if err != nil {
+		return err
+	}
+
+	if eh.outputDir != nil {
+		return eh.outputDir.WriteIndex(eh.ExecutedAt)
+	}
+
+	return nil
+}
+
+func checkTemplateParams(params *options.ParameterBag) error {
+	if !params.ReadBool(config.Template, false) {
+		return nil
+	}
+	if params.ReadBool(config.Detach, false) {
+		return fmt.Errorf("--%s can't be used with --%s", config.Template, config.Detach)
+	}
+	if params.ReadString(config.GroupIDs, "") != "" {
+		return fmt.Errorf("--%s can't be used with --%s, target the clients by ids, names or search", config.Template, config.GroupIDs)
+	}
+
+	return nil
+}
+
+func checkAggregateParams(params *options.ParameterBag) error {
+	if !params.ReadBool(config.Aggregate, false) {
+		return nil
+	}
+	if params.ReadBool(config.Detach, false) {
+		return fmt.Errorf("--%s can't be used with --%s, use it with 'job wait' instead", config.Aggregate, config.Detach)
+	}
+	if params.ReadBool(config.IsFullOutput, false) {
+		return fmt.Errorf("--%s can't be used with --%s", config.Aggregate, config.IsFullOutput)
+	}
+
 	return nil
 }
 
 func (eh *ExecutionHelper) buildExecInput(
 	params *options.ParameterBag,
-	clientIDs, scriptPayload, interpreter string,
+	clientIDs, command, scriptPayload, interpreter string,
 ) *models.WsScriptCommand {
 	wsCmd := &models.WsScriptCommand{
 		ClientIDs:           strings.Split(clientIDs, ","),
@@ -142,7 +599,7 @@ func (eh *ExecutionHelper) buildExecInput(
 	if scriptPayload != "" {
 		wsCmd.Script = scriptPayload
 	} else {
-		wsCmd.Command = params.ReadString(config.Command, "")
+		wsCmd.Command = command
 	}
 
 	groupIDsStr := params.ReadString(config.GroupIDs, "")
@@ -154,14 +611,24 @@ func (eh *ExecutionHelper) buildExecInput(
 	return wsCmd
 }
 
-func (eh *ExecutionHelper) sendCommand(wsCmd *models.WsScriptCommand) error {
+// startDetached starts the execution through the REST api, the results are available with 'job wait' or 'job get'
+func (eh *ExecutionHelper) startDetached(ctx context.Context, wsCmd *models.WsScriptCommand) error {
+	js, err := eh.Rport.StartMultiJob(ctx, wsCmd)
+	if err != nil {
+		return err
+	}
+
+	return eh.JobRenderer.RenderJobStarted(js)
+}
+
+func sendCommand(rw ReadWriter, wsCmd *models.WsScriptCommand) error {
 	wsCmdJSON, err := json.Marshal(wsCmd)
 	if err != nil {
 		return err
 	}
 	logrus.Debugf("will send %s", string(wsCmdJSON))
 
-	_, err = eh.ReadWriter.Write(wsCmdJSON)
+	_, err = rw.Write(wsCmdJSON)
 	if err != nil {
 		return err
 	}
@@ -169,10 +636,17 @@ func (eh *ExecutionHelper) sendCommand(wsCmd *models.WsScriptCommand) error {
 	return nil
 }
 
-func (eh *ExecutionHelper) getClientIDsFromParams(ctx context.Context, params *options.ParameterBag) (clientIDs string, err error) {
+// getClientIDsFromParams returns the ids given by --cids or the ids of the connected clients matching --names or --search,
+// the names are only known for the clients found by --names or --search
+func getClientIDsFromParams(
+	ctx context.Context,
+	rport *api.Rport,
+	params *options.ParameterBag,
+) (clientIDs string, clientNames map[string]string, err error) {
+	clientNames = map[string]string{}
 	ids := params.ReadString(config.ClientIDs, "")
 	if ids != "" {
-		return ids, nil
+		return ids, clientNames, nil
 	}
 	var combinedSearchString string
 	if names := config.ReadClientNames(params); names != "" {
@@ -180,42 +654,43 @@ func (eh *ExecutionHelper) getClientIDsFromParams(ctx context.Context, params *o
 	} else if search := params.ReadString(config.ClientCombinedSearchFlag, ""); search != "" {
 		combinedSearchString = search
 	} else {
-		return "", errors.New("no client ids, names or search provided")
+		return "", nil, errors.New("no client ids, names or search provided")
 	}
 
 	filter, err := api.NewFilterFromCombinedSearchString(combinedSearchString)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	clients, err := eh.Rport.Clients(
-		ctx,
-		api.NewPaginationWithLimit(api.ClientsLimitMax),
-		filter,
-	)
+	clients, err := rport.AllClients(ctx, filter)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	debugList := ""
-	for _, cl := range clients.Data {
+	for _, cl := range clients {
 		if cl.DisconnectedAt != "" {
 			continue
 		}
 		clientIDs += cl.ID + ","
+		clientNames[cl.ID] = cl.Name
 		debugList += cl.Name + " " + cl.ID + "\n"
 	}
 
 	clientIDs = strings.Trim(clientIDs, ",")
 	logrus.Debugf("received client list for execution:\n%s", debugList)
 
-	return clientIDs, nil
+	return clientIDs, clientNames, nil
 }
 
-func (eh *ExecutionHelper) startReading(ctx context.Context) error {
+// startReading processes the messages of the running jobs until the server closes the connection,
+// interrupted tells if it was stopped by a signal instead, an ended context is returned as error
+func (eh *ExecutionHelper) startReading(ctx context.Context, conn *connection) (interrupted bool, err error) {
+	rw := conn.rw
 	errsChan := make(chan error, 1)
 	msgChan := make(chan []byte, 1)
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
 
 	go func() {
 		defer close(msgChan)
@@ -224,41 +699,53 @@ func (eh *ExecutionHelper) startReading(ctx context.Context) error {
 			case <-ctx.Done():
 				return
 			default:
-				msg, err := eh.ReadWriter.Read()
-				if err != nil {
-					if err == io.EOF {
+				msg, readErr := rw.Read()
+				if readErr != nil {
+					if readErr == io.EOF {
 						return
 					}
-					errsChan <- err
+					errsChan <- readErr
 				}
 				msgChan <- msg
 			}
 		}
 	}()
 
-mainLoop:
 	for {
 		select {
 		case <-sigs:
-			break mainLoop
+			return true, nil
+		case <-ctx.Done():
+			return false, contextError(ctx)
 		case msg, ok := <-msgChan:
 			if !ok {
-				return nil
+				return false, contextError(ctx)
 			}
-			err := eh.processRawMessage(msg)
+			err = eh.processRawMessage(conn, msg)
 			if err != nil {
-				return err
+				return false, err
 			}
 			logrus.Debug(waitingMsg)
-		case err := <-errsChan:
-			return err
+		case err = <-errsChan:
+			return false, err
 		}
 	}
+}
 
-	return nil
+// contextError tells why reading stopped before the server closed the connection, an expired global --timeout
+// is an error rather than an interruption by the user
+func contextError(ctx context.Context) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return errors.New("the global --timeout expired before all jobs finished")
+	case ctx.Err() != nil:
+		return fmt.Errorf("stopped reading the jobs: %w", ctx.Err())
+	default:
+		return nil
+	}
 }
 
-func (eh *ExecutionHelper) processRawMessage(msg []byte) error {
+func (eh *ExecutionHelper) processRawMessage(conn *connection, msg []byte) error {
 	var job models.Job
 	err := json.Unmarshal(msg, &job)
 	if err != nil || job.Jid == "" {
@@ -274,8 +761,19 @@ func (eh *ExecutionHelper) processRawMessage(msg []byte) error {
 
 	logrus.Debugf("received message: '%s'", string(msg))
 
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
 	if !job.FinishedAt.IsZero() {
+		job.Attempt = conn.attempt
+		conn.jobs = append(conn.jobs, &job)
 		eh.ExecutionResults = append(eh.ExecutionResults, &job)
+		if eh.outputDir != nil {
+			err = eh.outputDir.WriteJob(&job)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	err = eh.JobRenderer.RenderJob(&job)
@@ -3,13 +3,20 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -78,7 +85,8 @@ func TestShouldSaveSimpleExecLog(t *testing.T) {
 	hostInfo := makeBasicTestHostInfo(t)
 
 	err := ic.Start(context.Background(), params, nil, hostInfo)
-	assert.NoError(t, err)
+	// the jobs are written to the exec log even if they have failed
+	assertExitCode(t, err, ExitCodeAllFailed)
 
 	jobRespAsArray := []*models.Job{
 		jobResp,
@@ -128,7 +136,7 @@ func TestShouldSaveSimpleExecLogForScript(t *testing.T) {
 	hostInfo := makeBasicTestHostInfo(t)
 
 	err := ic.Start(context.Background(), params, nil, hostInfo)
-	assert.NoError(t, err)
+	assertExitCode(t, err, ExitCodeAllFailed)
 
 	jobRespAsArray := []*models.Job{
 		jobResp,
@@ -185,7 +193,7 @@ func TestShouldSaveSimpleExecLogWithConfirmation(t *testing.T) {
 	hostInfo := makeBasicTestHostInfo(t)
 
 	err = ic.Start(context.Background(), params, prm, hostInfo)
-	assert.NoError(t, err)
+	assertExitCode(t, err, ExitCodeAllFailed)
 
 	jobRespAsArray := []*models.Job{
 		jobResp,
@@ -237,7 +245,7 @@ func TestShouldSaveMoreComplexExecLog(t *testing.T) {
 	hostInfo := makeBasicTestHostInfo(t)
 
 	err := ic.Start(context.Background(), params, nil, hostInfo)
-	assert.NoError(t, err)
+	assertExitCode(t, err, ExitCodeSomeFailed)
 
 	ec, err := os.ReadFile(sourceLogFilename)
 	assert.NoError(t, err)
@@ -347,7 +355,7 @@ func TestShouldSaveExecLogForFailedClientIDs(t *testing.T) {
 	}
 
 	err := ic.Start(context.Background(), params, prm, hostInfo)
-	assert.NoError(t, err)
+	assertExitCode(t, err, ExitCodeAllFailed)
 
 	ec, err := os.ReadFile(expectedLogResultsFilename)
 	assert.NoError(t, err)
@@ -493,3 +501,189 @@ func makeReadWriterMockFromJobs(t *testing.T, jobs []*models.Job) (m *ReadWriter
 	}
 	return rwMock
 }
+
+func assertExitCode(t *testing.T, err error, expectedCode int) {
+	t.Helper()
+
+	exitErr := &ExitError{}
+	if assert.ErrorAs(t, err, &exitErr) {
+		assert.Equal(t, expectedCode, exitErr.Code)
+	}
+}
+
+func TestCheckJobResults(t *testing.T) {
+	successful := &models.Job{Status: "successful"}
+	failed := &models.Job{Status: statusFailed}
+	someFailed := []*models.Job{successful, failed, successful, successful}
+	reported := &models.Job{ClientID: "cl1", Status: models.JobStatusSuccessful}
+	timedOut := &models.Job{ClientID: "cl2", Status: models.JobStatusUnknown}
+
+	testCases := []struct {
+		name          string
+		targets       []string
+		jobs          []*models.Job
+		failThreshold string
+		interrupted   bool
+		expectedCode  int
+	}{
+		{
+			name: "all successful",
+			jobs: []*models.Job{successful, successful},
+		},
+		{
+			name:         "some failed",
+			jobs:         someFailed,
+			expectedCode: ExitCodeSomeFailed,
+		},
+		{
+			name:         "all failed",
+			jobs:         []*models.Job{failed, failed},
+			expectedCode: ExitCodeAllFailed,
+		},
+		{
+			name:          "failures tolerated by count",
+			jobs:          someFailed,
+			failThreshold: "1",
+		},
+		{
+			name:          "failures tolerated by percentage",
+			jobs:          someFailed,
+			failThreshold: "25%",
+		},
+		{
+			name:          "failures above percentage",
+			jobs:          someFailed,
+			failThreshold: "20%",
+			expectedCode:  ExitCodeSomeFailed,
+		},
+		{
+			name:         "interrupted",
+			jobs:         []*models.Job{successful},
+			interrupted:  true,
+			expectedCode: ExitCodeInterrupted,
+		},
+		{
+			name:         "timed out",
+			targets:      []string{"cl1", "cl2"},
+			jobs:         []*models.Job{reported, timedOut},
+			expectedCode: ExitCodeSomeFailed,
+		},
+		{
+			name:         "not reported back",
+			targets:      []string{"cl1", "cl2"},
+			jobs:         []*models.Job{reported},
+			expectedCode: ExitCodeSomeFailed,
+		},
+		{
+			name:         "no job reported back",
+			targets:      []string{"cl1", "cl2"},
+			expectedCode: ExitCodeAllFailed,
+		},
+		{
+			name:          "not reported back tolerated",
+			targets:       []string{"cl1", "cl2"},
+			jobs:          []*models.Job{reported},
+			failThreshold: "50%",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			threshold, err := utils.ParseThreshold(tc.failThreshold)
+			require.NoError(t, err)
+
+			err = checkJobResults(tc.targets, tc.jobs, threshold, tc.interrupted)
+			if tc.expectedCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assertExitCode(t, err, tc.expectedCode)
+		})
+	}
+}
+
+func TestCommandExecutionWithoutTargets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.ClientsURL, r.URL.Path)
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.ClientsResponse{Data: []*models.Client{}}))
+	}))
+	defer srv.Close()
+
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{
+			Rport: api.New(srv.URL, nil),
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientNamesFlag: "unknown*",
+		config.Command:         "cmd",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeNoTargets)
+	assert.EqualError(t, err, "no clients match your targeting criteria")
+}
+
+func TestCommandExecutionNamesUnreportedClients(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.ClientsURL, r.URL.Path)
+		clients := []*models.Client{{ID: "cl1", Name: "web1"}, {ID: "cl2", Name: "web2"}}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.ClientsResponse{Data: clients}))
+	}))
+	defer srv.Close()
+
+	jr := &JobRendererMock{}
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{
+			ReadWriter:  makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl1")),
+			JobRenderer: jr,
+			Rport:       api.New(srv.URL, nil),
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientNamesFlag: "web*",
+		config.Command:         "cmd",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeSomeFailed)
+
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, []*models.SummaryClient{{ID: "cl2", Name: "web2"}}, jr.summaryToRender.NotReported)
+}
+
+func TestCommandExecutionWithInvalidFailThreshold(t *testing.T) {
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:     "1235",
+		config.Command:       "cmd",
+		config.FailThreshold: "ten",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assert.EqualError(t, err, `--fail-threshold: invalid threshold "ten": expected a non-negative number like 3 or a percentage like 10%`)
+}
+
+func TestCommandExecutionTimeoutExpired(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{
+			ReadWriter:  makeReadWriterMockFromJobs(t, nil),
+			JobRenderer: &JobRendererMock{},
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "cl1",
+		config.Command:   "pwd",
+	})
+	err := cc.Start(ctx, params, nil, nil)
+	assert.EqualError(t, err, "the global --timeout expired before all jobs finished")
+	exitErr := &ExitError{}
+	assert.False(t, errors.As(err, &exitErr))
+}
@@ -19,7 +19,7 @@ const (
 	overwriteExistingLogFileMsg    = "Overwrite existing execution log file (y/n): "
 	WillBeExecutedWithClientIDsMsg = "Your task will be executed on the following clients:"
 	proceedWithClientIDsMsg        = "Proceed with the above client IDs (y/n): "
-	statusFailed                   = "failed"
+	statusFailed                   = models.JobStatusFailed
 )
 
 var (
@@ -52,6 +52,20 @@ type ExecutionLogInfo struct {
 	Jobs       []*models.Job `yaml:"jobs"`
 }
 
+// Header returns the execution info of the log without the jobs
+func (li *ExecutionLogInfo) Header() *models.ExecLogHeader {
+	return &models.ExecLogHeader{
+		ExecutedAt: li.ExecutedAt,
+		ExecutedBy: li.ExecutedBy,
+		ExecutedOn: li.ExecutedOn,
+		APIUser:    li.APIUser,
+		APIURL:     li.APIURL,
+		APIAuth:    li.APIAuth,
+		NumClients: li.NumClients,
+		Failed:     li.Failed,
+	}
+}
+
 func NewExecLog(params *options.ParameterBag,
 	logFilename string,
 	promptReader config.PromptReader,
@@ -184,9 +198,10 @@ func (el *ExecutionLog) getNumClients() (numClients int) {
 	return len(cids)
 }
 
+// getNumClientsWithFailedJobs counts the clients whose last attempt has failed
 func (el *ExecutionLog) getNumClientsWithFailedJobs() (failedJobCount int) {
 	cids := make(map[string]bool, 8)
-	for _, job := range el.logInfo.Jobs {
+	for _, job := range finalJobs(el.logInfo.Jobs) {
 		if job.Status == statusFailed {
 			cids[job.ClientID] = true
 		}
@@ -194,21 +209,21 @@ func (el *ExecutionLog) getNumClientsWithFailedJobs() (failedJobCount int) {
 	return len(cids)
 }
 
-func (el *ExecutionLog) GetAndConfirmFailedClientIDs() (clientIDs string, err error) {
+func (el *ExecutionLog) GetAndConfirmFailedClientIDs() (clientIDs string, clientNames map[string]string, err error) {
 	_, err = el.ReadFile()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	ids := make(map[string]string, 0)
-	for _, job := range el.logInfo.Jobs {
+	for _, job := range finalJobs(el.logInfo.Jobs) {
 		if job.Status == statusFailed {
 			ids[job.ClientID] = job.ClientName
 		}
 	}
 
 	if len(ids) == 0 {
-		return "", ErrNoClientIDsToUse
+		return "", nil, ErrNoClientIDsToUse
 	}
 
 	displayClientIDs(ids)
@@ -216,15 +231,15 @@ func (el *ExecutionLog) GetAndConfirmFailedClientIDs() (clientIDs string, err er
 	if el.promptReader != nil && !config.ReadNoPrompt(el.params) {
 		proceed, err := el.promptReader.ReadConfirmation(proceedWithClientIDsMsg)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 		if !proceed {
-			return "", ErrClientIDsNotConfirmed
+			return "", nil, ErrClientIDsNotConfirmed
 		}
 	}
 
 	clientIDs = strings.Join(getKeysFromMap(ids), ",")
-	return clientIDs, nil
+	return clientIDs, ids, nil
 }
 
 func getKeysFromMap(ids map[string]string) (keys []string) {
@@ -245,3 +260,81 @@ func displayClientIDs(ids map[string]string) {
 		}
 	}
 }
+
+type ExecLogRenderer interface {
+	RenderExecLogHeader(h *models.ExecLogHeader) error
+	RenderExecLogDiffs(diffs []*models.ExecLogDiff) error
+}
+
+// ExecLogController works on execution logs written by --write-execlog without connecting to the server
+type ExecLogController struct {
+	ExecLogRenderer ExecLogRenderer
+	JobRenderer     JobRenderer
+}
+
+// Show renders the header and the jobs of an execution log, --status and --client select the jobs to render
+func (elc *ExecLogController) Show(params *options.ParameterBag, logFilename string) error {
+	filter, err := readExecLogFilter(params)
+	if err != nil {
+		return err
+	}
+
+	logInfo, err := NewExecLog(nil, logFilename, nil, nil).ReadFile()
+	if err != nil {
+		return err
+	}
+
+	err = elc.ExecLogRenderer.RenderExecLogHeader(logInfo.Header())
+	if err != nil {
+		return err
+	}
+
+	for _, j := range logInfo.Jobs {
+		if !filter.matches(j) {
+			continue
+		}
+		err = elc.JobRenderer.RenderJob(j)
+		if err != nil {
+			return err
+		}
+	}
+
+	return elc.JobRenderer.Flush()
+}
+
+// Diff renders the clients whose status or output differ between two execution logs, e.g. a run before a change
+// and a run after it
+func (elc *ExecLogController) Diff(beforeFilename, afterFilename string) error {
+	before, err := NewExecLog(nil, beforeFilename, nil, nil).ReadFile()
+	if err != nil {
+		return err
+	}
+
+	after, err := NewExecLog(nil, afterFilename, nil, nil).ReadFile()
+	if err != nil {
+		return err
+	}
+
+	return elc.ExecLogRenderer.RenderExecLogDiffs(diffExecLogs(beforeFilename, before, afterFilename, after))
+}
+
+// Convert writes the jobs of an existing execution log to the reports given by --report
+func (elc *ExecLogController) Convert(logFilename string, reportValues []string) error {
+	reports, err := ParseReports(reportValues)
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		return fmt.Errorf(
+			"no report requested, use --%s %s=FILE or --%s %s=FILE",
+			config.Report, ReportFormatJUnit, config.Report, ReportFormatTAP,
+		)
+	}
+
+	logInfo, err := NewExecLog(nil, logFilename, nil, nil).ReadFile()
+	if err != nil {
+		return err
+	}
+
+	return WriteReports(reports, logInfo)
+}
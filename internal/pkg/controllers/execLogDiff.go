@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const execLogDiffContextLines = 3
+
+var execLogJobStatuses = []string{
+	models.JobStatusSuccessful,
+	models.JobStatusFailed,
+	models.JobStatusUnknown,
+	models.JobStatusRunning,
+}
+
+// execLogFilter selects the jobs of an execution log by status and by client id or name, wildcards (*) are
+// supported for the clients
+type execLogFilter struct {
+	statuses map[string]bool
+	clients  []string
+}
+
+func readExecLogFilter(params *options.ParameterBag) (*execLogFilter, error) {
+	filter := &execLogFilter{
+		statuses: map[string]bool{},
+		clients:  splitFieldsList(params.ReadString(config.ClientID, "")),
+	}
+
+	for _, status := range splitFieldsList(params.ReadString(config.JobStatus, "")) {
+		if !isExecLogJobStatus(status) {
+			return nil, fmt.Errorf("unknown status %q, use %s", status, strings.Join(execLogJobStatuses, ", "))
+		}
+		filter.statuses[status] = true
+	}
+
+	for _, pattern := range filter.clients {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid --%s %q: %v", config.ClientID, pattern, err)
+		}
+	}
+
+	return filter, nil
+}
+
+func (f *execLogFilter) matches(j *models.Job) bool {
+	if len(f.statuses) > 0 && !f.statuses[j.Status] {
+		return false
+	}
+	if len(f.clients) == 0 {
+		return true
+	}
+
+	for _, pattern := range f.clients {
+		// the patterns have been validated by readExecLogFilter
+		if matchedID, _ := path.Match(pattern, j.ClientID); matchedID {
+			return true
+		}
+		if matchedName, _ := path.Match(pattern, j.ClientName); matchedName && j.ClientName != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isExecLogJobStatus(status string) bool {
+	for _, s := range execLogJobStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// diffExecLogs compares the jobs of the clients in both logs by client id, clients which are missing in one of
+// the logs or whose status or output changed are returned ordered by client name or id
+func diffExecLogs(beforeName string, before *ExecutionLogInfo, afterName string, after *ExecutionLogInfo) []*models.ExecLogDiff {
+	beforeJobs := jobsByClientID(before.Jobs)
+	afterJobs := jobsByClientID(after.Jobs)
+
+	clientIDs := make([]string, 0, len(beforeJobs)+len(afterJobs))
+	for clientID := range beforeJobs {
+		clientIDs = append(clientIDs, clientID)
+	}
+	for clientID := range afterJobs {
+		if _, ok := beforeJobs[clientID]; !ok {
+			clientIDs = append(clientIDs, clientID)
+		}
+	}
+
+	diffs := make([]*models.ExecLogDiff, 0)
+	for _, clientID := range clientIDs {
+		d := diffClientJobs(beforeName, beforeJobs[clientID], afterName, afterJobs[clientID])
+		if d.StatusBefore != d.StatusAfter || d.StdoutDiff != "" || d.StderrDiff != "" {
+			diffs = append(diffs, d)
+		}
+	}
+
+	sort.Slice(diffs, func(i, k int) bool {
+		return execLogDiffSortKey(diffs[i]) < execLogDiffSortKey(diffs[k])
+	})
+
+	return diffs
+}
+
+// diffClientJobs compares the jobs of a client, one of the jobs is nil if the client is missing in its log
+func diffClientJobs(beforeName string, beforeJob *models.Job, afterName string, afterJob *models.Job) *models.ExecLogDiff {
+	d := &models.ExecLogDiff{}
+	var beforeResult, afterResult models.JobResult
+	for _, j := range []*models.Job{beforeJob, afterJob} {
+		if j != nil {
+			d.ClientID = j.ClientID
+			d.ClientName = j.ClientName
+		}
+	}
+	if beforeJob != nil {
+		d.StatusBefore = beforeJob.Status
+		beforeResult = beforeJob.Result
+	}
+	if afterJob != nil {
+		d.StatusAfter = afterJob.Status
+		afterResult = afterJob.Result
+	}
+	d.StdoutDiff = unifiedDiff(beforeName+" stdout", beforeResult.Stdout, afterName+" stdout", afterResult.Stdout)
+	d.StderrDiff = unifiedDiff(beforeName+" stderr", beforeResult.Stderr, afterName+" stderr", afterResult.Stderr)
+
+	return d
+}
+
+// jobsByClientID returns the last job of each client
+func jobsByClientID(jobs []*models.Job) map[string]*models.Job {
+	jobsByID := make(map[string]*models.Job, len(jobs))
+	for _, j := range jobs {
+		jobsByID[j.ClientID] = j
+	}
+	return jobsByID
+}
+
+func execLogDiffSortKey(d *models.ExecLogDiff) string {
+	if d.ClientName != "" {
+		return d.ClientName + "\x00" + d.ClientID
+	}
+	return d.ClientID + "\x00"
+}
+
+func unifiedDiff(fromLabel, from, toLabel, to string) string {
+	if from == to {
+		return ""
+	}
+
+	// errors can only occur when writing the diff, which is written to a string here
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(from),
+		B:        splitDiffLines(to),
+		FromFile: fromLabel,
+		ToFile:   toLabel,
+		Context:  execLogDiffContextLines,
+	})
+
+	return diff
+}
+
+// splitDiffLines splits the text into lines keeping the line breaks, a missing line break at the end is added
+// so the last line is rendered on its own line in the diff
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
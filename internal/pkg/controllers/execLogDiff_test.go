@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecLogFilter(t *testing.T) {
+	jobs := []*models.Job{
+		{ClientID: "cl1", ClientName: "web01", Status: models.JobStatusSuccessful},
+		{ClientID: "cl2", ClientName: "web02", Status: models.JobStatusFailed},
+		{ClientID: "cl3", Status: models.JobStatusUnknown},
+	}
+
+	testCases := []struct {
+		Name              string
+		Params            map[string]string
+		ExpectedClientIDs []string
+		ExpectedError     string
+	}{
+		{
+			Name:              "no filter",
+			ExpectedClientIDs: []string{"cl1", "cl2", "cl3"},
+		},
+		{
+			Name:              "statuses",
+			Params:            map[string]string{config.JobStatus: "failed, unknown"},
+			ExpectedClientIDs: []string{"cl2", "cl3"},
+		},
+		{
+			Name:              "client names with wildcard and client id",
+			Params:            map[string]string{config.ClientID: "web*,cl3"},
+			ExpectedClientIDs: []string{"cl1", "cl2", "cl3"},
+		},
+		{
+			Name:              "status and client",
+			Params:            map[string]string{config.JobStatus: "failed", config.ClientID: "web01"},
+			ExpectedClientIDs: []string{},
+		},
+		{
+			Name:          "unknown status",
+			Params:        map[string]string{config.JobStatus: "done"},
+			ExpectedError: `unknown status "done", use successful, failed, unknown, running`,
+		},
+		{
+			Name:          "invalid client pattern",
+			Params:        map[string]string{config.ClientID: "web[1"},
+			ExpectedError: `invalid --client "web[1": syntax error in pattern`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filter, err := readExecLogFilter(config.FromValues(tc.Params))
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			clientIDs := []string{}
+			for _, j := range jobs {
+				if filter.matches(j) {
+					clientIDs = append(clientIDs, j.ClientID)
+				}
+			}
+			assert.Equal(t, tc.ExpectedClientIDs, clientIDs)
+		})
+	}
+}
+
+func TestDiffExecLogs(t *testing.T) {
+	before := &ExecutionLogInfo{
+		Jobs: []*models.Job{
+			{ClientID: "cl1", ClientName: "web01", Status: models.JobStatusSuccessful, Result: models.JobResult{Stdout: "same\n"}},
+			{ClientID: "cl2", ClientName: "web02", Status: models.JobStatusFailed, Result: models.JobResult{Stderr: "no space left"}},
+			{ClientID: "cl3", ClientName: "db", Status: models.JobStatusSuccessful, Result: models.JobResult{Stdout: "a\nb\nc\n"}},
+			{ClientID: "cl4", Status: models.JobStatusSuccessful},
+		},
+	}
+	after := &ExecutionLogInfo{
+		Jobs: []*models.Job{
+			{ClientID: "cl1", ClientName: "web01", Status: models.JobStatusSuccessful, Result: models.JobResult{Stdout: "same\n"}},
+			{ClientID: "cl2", ClientName: "web02", Status: models.JobStatusSuccessful},
+			{ClientID: "cl3", ClientName: "db", Status: models.JobStatusSuccessful, Result: models.JobResult{Stdout: "a\nB\nc"}},
+			{ClientID: "cl5", ClientName: "app", Status: models.JobStatusSuccessful},
+		},
+	}
+
+	diffs := diffExecLogs("before.yaml", before, "after.yaml", after)
+
+	expected := []*models.ExecLogDiff{
+		{
+			ClientID:    "cl5",
+			ClientName:  "app",
+			StatusAfter: models.JobStatusSuccessful,
+		},
+		{
+			ClientID:     "cl4",
+			StatusBefore: models.JobStatusSuccessful,
+		},
+		{
+			ClientID:     "cl3",
+			ClientName:   "db",
+			StatusBefore: models.JobStatusSuccessful,
+			StatusAfter:  models.JobStatusSuccessful,
+			StdoutDiff: `--- before.yaml stdout
++++ after.yaml stdout
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			ClientID:     "cl2",
+			ClientName:   "web02",
+			StatusBefore: models.JobStatusFailed,
+			StatusAfter:  models.JobStatusSuccessful,
+			StderrDiff: `--- before.yaml stderr
++++ after.yaml stderr
+@@ -1 +0,0 @@
+-no space left
+`,
+		},
+	}
+	assert.Equal(t, expected, diffs)
+}
+
+func TestExecLogShow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	elc := &ExecLogController{
+		ExecLogRenderer: &output.ExecLogRenderer{Writer: buf, Format: output.FormatJSON},
+		JobRenderer:     &output.JobRenderer{Writer: buf, Format: output.FormatJSON},
+	}
+
+	params := config.FromValues(map[string]string{config.JobStatus: "failed"})
+	err := elc.Show(params, "../../../testdata/execlog-3jobs1failed.yaml")
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `"executed_by":"test_user"`)
+	assert.Contains(t, buf.String(), `"client_name":"ITXC"`)
+	assert.NotContains(t, buf.String(), "Kathy")
+}
+
+func TestExecLogDiffWithoutDifferences(t *testing.T) {
+	buf := &bytes.Buffer{}
+	elc := &ExecLogController{
+		ExecLogRenderer: &output.ExecLogRenderer{Writer: buf, Format: output.FormatHuman},
+	}
+
+	logFile := "../../../testdata/execlog-3jobs1failed.yaml"
+	err := elc.Diff(logFile, logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "no differences\n", buf.String())
+}
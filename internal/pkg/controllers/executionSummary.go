@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const (
+	statusSuccessful = models.JobStatusSuccessful
+	statusTimedOut   = models.JobStatusUnknown
+)
+
+// completeRendering renders the jobs held back by the renderer followed by the summary of a multi-client execution,
+// clientNames are the names of the targeted clients known so far to name the clients which didn't report back
+func completeRendering(jr JobRenderer, clientIDs []string, clientNames map[string]string, jobs []*models.Job) error {
+	err := jr.Flush()
+	if err != nil {
+		return err
+	}
+
+	es := newExecutionSummary(clientIDs, clientNames, jobs)
+	if es.Targeted <= 1 {
+		return nil
+	}
+
+	return jr.RenderSummary(es)
+}
+
+// newExecutionSummary counts the finished jobs by their status and the targeted clients without a finished job,
+// the durations are taken from the jobs with a known start time
+func newExecutionSummary(clientIDs []string, clientNames map[string]string, jobs []*models.Job) *models.ExecutionSummary {
+	es := &models.ExecutionSummary{
+		Finished:    len(jobs),
+		NotReported: []*models.SummaryClient{},
+	}
+
+	reported := make(map[string]bool, len(jobs))
+	durationsCount := 0
+	sumDuration := 0.0
+	es.MinDurationSec = math.MaxFloat64
+	for _, j := range jobs {
+		reported[j.ClientID] = true
+
+		switch j.Status {
+		case statusSuccessful:
+			es.Succeeded++
+		case statusFailed:
+			es.Failed++
+		case statusTimedOut:
+			es.TimedOut++
+		}
+
+		if j.StartedAt.IsZero() || j.FinishedAt.IsZero() {
+			continue
+		}
+		duration := j.FinishedAt.Sub(j.StartedAt).Seconds()
+		durationsCount++
+		sumDuration += duration
+		es.MinDurationSec = math.Min(es.MinDurationSec, duration)
+		es.MaxDurationSec = math.Max(es.MaxDurationSec, duration)
+	}
+
+	if durationsCount > 0 {
+		es.AvgDurationSec = sumDuration / float64(durationsCount)
+	} else {
+		es.MinDurationSec = 0
+	}
+
+	targeted := make(map[string]bool, len(clientIDs))
+	for _, id := range clientIDs {
+		if id == "" || targeted[id] {
+			continue
+		}
+		targeted[id] = true
+		if !reported[id] {
+			es.NotReported = append(es.NotReported, &models.SummaryClient{ID: id, Name: clientNames[id]})
+		}
+	}
+	// clients of the targeted groups are only known when they report back
+	for id := range reported {
+		targeted[id] = true
+	}
+	es.Targeted = len(targeted)
+	sort.Slice(es.NotReported, func(i, j int) bool {
+		return es.NotReported[i].ID < es.NotReported[j].ID
+	})
+
+	return es
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExecutionSummary(t *testing.T) {
+	startedAt := time.Date(2022, 7, 13, 5, 57, 0, 0, time.UTC)
+	jobs := []*models.Job{
+		{ClientID: "1", Status: "successful", StartedAt: startedAt, FinishedAt: startedAt.Add(time.Second)},
+		{ClientID: "2", Status: "failed", StartedAt: startedAt, FinishedAt: startedAt.Add(3 * time.Second)},
+		{ClientID: "3", Status: "unknown", StartedAt: startedAt, FinishedAt: startedAt.Add(5 * time.Second)},
+		// a client of a targeted group
+		{ClientID: "6", Status: "successful", FinishedAt: startedAt},
+	}
+
+	es := newExecutionSummary([]string{"1", "2", "3", "5", "4"}, map[string]string{"1": "web1", "4": "db4"}, jobs)
+
+	assert.Equal(t, &models.ExecutionSummary{
+		Targeted:       6,
+		Finished:       4,
+		Succeeded:      2,
+		Failed:         1,
+		TimedOut:       1,
+		NotReported:    []*models.SummaryClient{{ID: "4", Name: "db4"}, {ID: "5"}},
+		MinDurationSec: 1,
+		AvgDurationSec: 3,
+		MaxDurationSec: 5,
+	}, es)
+}
+
+func TestNewExecutionSummaryWithoutJobs(t *testing.T) {
+	es := newExecutionSummary([]string{"1", "2"}, nil, []*models.Job{})
+
+	assert.Equal(t, &models.ExecutionSummary{
+		Targeted:    2,
+		NotReported: []*models.SummaryClient{{ID: "1"}, {ID: "2"}},
+	}, es)
+}
+
+func TestCompleteRenderingSkipsSummaryOfSingleClient(t *testing.T) {
+	jr := &JobRendererMock{}
+	err := completeRendering(jr, []string{"1"}, nil, []*models.Job{{ClientID: "1", Status: "successful"}})
+	assert.NoError(t, err)
+	assert.True(t, jr.flushed)
+	assert.Nil(t, jr.summaryToRender)
+
+	err = completeRendering(jr, []string{"1", "2"}, map[string]string{"2": "web2"}, []*models.Job{{ClientID: "1", Status: "successful"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.SummaryClient{{ID: "2", Name: "web2"}}, jr.summaryToRender.NotReported)
+}
@@ -0,0 +1,21 @@
+package controllers
+
+// exit codes telling scripts and CI pipelines about the results of an execution, 1 is used for all other errors
+const (
+	ExitCodeSomeFailed = 2
+	ExitCodeAllFailed  = 3
+	ExitCodeNoTargets  = 4
+	// the rollout was stopped before all batches were executed
+	ExitCodeRolloutStopped = 5
+	ExitCodeInterrupted    = 130
+)
+
+// ExitError makes rportcli exit with the given code instead of 1
+type ExitError struct {
+	Code int
+	Msg  string
+}
+
+func (ee *ExitError) Error() string {
+	return ee.Msg
+}
@@ -0,0 +1,418 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+const DefaultJobWaitInterval = 2 * time.Second
+
+type JobHistoryRenderer interface {
+	RenderJobs(jobs []*models.Job) error
+	RenderMultiJobs(multiJobs []*models.MultiJob) error
+}
+
+type JobController struct {
+	Rport              *api.Rport
+	JobHistoryRenderer JobHistoryRenderer
+	JobRenderer        JobRenderer
+	ClientPicker       ClientPicker
+	Now                func() time.Time
+}
+
+// List renders the command history of a client given by --client or --name,
+// without a client the history of multi-client jobs is rendered
+func (jc *JobController) List(ctx context.Context, params *options.ParameterBag) error {
+	filter, err := readJobsFilter(params, jc.now())
+	if err != nil {
+		return err
+	}
+
+	cl, err := jc.readClient(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	pagination := api.NewPaginationFromParams(params)
+	if cl == nil {
+		resp, e := jc.Rport.MultiJobs(ctx, pagination, filter)
+		if e != nil {
+			return e
+		}
+		warnJobsTruncated(resp.Truncated(pagination), len(resp.Data), resp.Meta.Count)
+
+		return jc.JobHistoryRenderer.RenderMultiJobs(resp.Data)
+	}
+
+	resp, err := jc.Rport.ClientJobs(ctx, cl.ID, pagination, filter)
+	if err != nil {
+		return err
+	}
+	warnJobsTruncated(resp.Truncated(pagination), len(resp.Data), resp.Meta.Count)
+	for _, j := range resp.Data {
+		if j.ClientID == "" {
+			j.ClientID = cl.ID
+		}
+		if j.ClientName == "" {
+			j.ClientName = cl.Name
+		}
+	}
+
+	return jc.JobHistoryRenderer.RenderJobs(resp.Data)
+}
+
+// Get renders a finished job the same way as the output of command execute. With --client or --name the jid is
+// the id of a job of this client, otherwise it's the id of a multi-client job and the jobs of all its clients are rendered
+func (jc *JobController) Get(ctx context.Context, params *options.ParameterBag, jid string) error {
+	if jid == "" {
+		return errors.New("no job id provided")
+	}
+
+	cl, err := jc.readClient(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	var jobs []*models.Job
+	if cl == nil {
+		mj, e := jc.Rport.MultiJob(ctx, jid)
+		if e != nil {
+			return e
+		}
+		jobs = mj.Jobs
+	} else {
+		j, e := jc.Rport.ClientJob(ctx, cl.ID, jid)
+		if e != nil {
+			return e
+		}
+		if j.ClientID == "" {
+			j.ClientID = cl.ID
+		}
+		jobs = []*models.Job{j}
+	}
+
+	err = jc.addClientNames(ctx, jobs, cl)
+	if err != nil {
+		return err
+	}
+
+	for _, j := range jobs {
+		err = jc.JobRenderer.RenderJob(j)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Wait polls a multi-client job until the jobs of all clients have finished. Every finished job is rendered as soon
+// as it's noticed, unless --aggregate holds them back until the end, and the results are written to the
+// --write-execlog file like an attached execution would do
+func (jc *JobController) Wait(
+	ctx context.Context,
+	params *options.ParameterBag,
+	jid string,
+	promptReader config.PromptReader,
+	sigs chan os.Signal,
+) error {
+	if jid == "" {
+		return errors.New("no job id provided")
+	}
+
+	interval, err := readPollInterval(params, DefaultJobWaitInterval)
+	if err != nil {
+		return err
+	}
+	err = checkAggregateParams(params)
+	if err != nil {
+		return err
+	}
+	failThreshold, err := readFailThreshold(params)
+	if err != nil {
+		return err
+	}
+
+	var el *ExecutionLog
+	execLogRequested, logFilename := config.ExecLogRequested(params)
+	if execLogRequested {
+		el = NewExecLog(params, logFilename, promptReader, nil)
+		if el.ExistingLog() {
+			_, err = el.ConfirmOverwrite()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	mj, interrupted, err := jc.waitForMultiJob(ctx, jid, interval, sigs)
+	if mj == nil {
+		return err
+	}
+	clientNames := jc.unreportedClientNames(ctx, mj)
+	completeErr := completeRendering(jc.JobRenderer, mj.ClientIDs, clientNames, finishedJobs(mj.Jobs))
+	if err != nil {
+		return err
+	}
+	if completeErr != nil {
+		return completeErr
+	}
+
+	if execLogRequested && el.ShouldWriteLog() {
+		err = el.WriteExecLog(mj.StartedAt, finishedJobs(mj.Jobs))
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkJobResults(mj.ClientIDs, finishedJobs(mj.Jobs), failThreshold, interrupted)
+}
+
+// waitForMultiJob returns the last state of the multi-client job once it's finished or the wait is interrupted,
+// an ended context is returned as error like by an attached execution
+func (jc *JobController) waitForMultiJob(
+	ctx context.Context,
+	jid string,
+	interval time.Duration,
+	sigs chan os.Signal,
+) (mj *models.MultiJob, interrupted bool, err error) {
+	rendered := map[string]bool{}
+	for {
+		current, fetchErr := jc.Rport.MultiJob(ctx, jid)
+		switch {
+		case fetchErr != nil && ctx.Err() != nil:
+			return mj, false, contextError(ctx)
+		case fetchErr != nil && mj == nil:
+			return nil, false, fetchErr
+		case fetchErr != nil:
+			// keep waiting, the server might be restarted or the network might be flaky
+			logrus.Warnf("failed to fetch job %s: %v", jid, fetchErr)
+		default:
+			mj = current
+			err = jc.renderNewlyFinishedJobs(ctx, mj.Jobs, rendered)
+			if err != nil {
+				return mj, false, err
+			}
+			if multiJobFinished(mj) {
+				return mj, false, nil
+			}
+			logrus.Debugf("%d of %d jobs of %s have finished", len(rendered), len(mj.ClientIDs), jid)
+		}
+
+		select {
+		case <-ctx.Done():
+			return mj, false, contextError(ctx)
+		case <-sigs:
+			return mj, true, nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (jc *JobController) renderNewlyFinishedJobs(ctx context.Context, jobs []*models.Job, rendered map[string]bool) error {
+	newlyFinished := make([]*models.Job, 0)
+	for _, j := range finishedJobs(jobs) {
+		if !rendered[j.Jid] {
+			newlyFinished = append(newlyFinished, j)
+		}
+	}
+	if len(newlyFinished) == 0 {
+		return nil
+	}
+
+	err := jc.addClientNames(ctx, newlyFinished, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, j := range newlyFinished {
+		rendered[j.Jid] = true
+		err = jc.JobRenderer.RenderJob(j)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// multiJobFinished tells if all clients have finished their jobs. With abort on error the server doesn't start
+// the remaining jobs after a failed one. Without known client ids, e.g. when only groups are targeted, a multi job
+// is finished when at least one job exists and all existing jobs have finished.
+func multiJobFinished(mj *models.MultiJob) bool {
+	failed := false
+	for _, j := range mj.Jobs {
+		if j.FinishedAt.IsZero() {
+			return false
+		}
+		if j.Status == statusFailed {
+			failed = true
+		}
+	}
+
+	if mj.AbortOnErr && failed {
+		return true
+	}
+	if len(mj.ClientIDs) == 0 {
+		return len(mj.Jobs) > 0
+	}
+
+	return len(mj.Jobs) >= len(mj.ClientIDs)
+}
+
+func finishedJobs(jobs []*models.Job) []*models.Job {
+	finished := make([]*models.Job, 0, len(jobs))
+	for _, j := range jobs {
+		if !j.FinishedAt.IsZero() {
+			finished = append(finished, j)
+		}
+	}
+	return finished
+}
+
+// readClient returns the client given by --client or --name or nil if none is given, only id and name are known
+// for a client given by id to avoid an extra request
+func (jc *JobController) readClient(ctx context.Context, params *options.ParameterBag) (*models.Client, error) {
+	if id := params.ReadString(config.ClientID, ""); id != "" {
+		return &models.Client{ID: id}, nil
+	}
+
+	name := params.ReadString(config.ClientNameFlag, "")
+	if name == "" {
+		return nil, nil
+	}
+
+	return findClientByName(ctx, jc.Rport, name, pickerUnlessNoPrompt(jc.ClientPicker, params))
+}
+
+// addClientNames fills in the client names the server doesn't store with the jobs so that they are rendered
+// like the live output of command execute
+func (jc *JobController) addClientNames(ctx context.Context, jobs []*models.Job, cl *models.Client) error {
+	names := map[string]string{}
+	if cl != nil && cl.Name != "" {
+		names[cl.ID] = cl.Name
+	}
+
+	missing := []string{}
+	for _, j := range jobs {
+		if j.ClientName == "" && names[j.ClientID] == "" {
+			missing = append(missing, j.ClientID)
+		}
+	}
+
+	if len(missing) > 0 {
+		clients, err := jc.Rport.AllClientsWithFields(
+			ctx,
+			api.NewFilters("id", strings.Join(missing, ",")),
+			api.Fields{"id", "name"},
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		for _, c := range clients {
+			names[c.ID] = c.Name
+		}
+	}
+
+	for _, j := range jobs {
+		if j.ClientName == "" {
+			j.ClientName = names[j.ClientID]
+		}
+	}
+
+	return nil
+}
+
+// unreportedClientNames returns the names of the targeted clients without a finished job to name them in the summary,
+// the names are taken from their unfinished jobs or fetched, a failed fetch leaves them unnamed
+func (jc *JobController) unreportedClientNames(ctx context.Context, mj *models.MultiJob) map[string]string {
+	names := map[string]string{}
+	reported := map[string]bool{}
+	for _, j := range mj.Jobs {
+		names[j.ClientID] = j.ClientName
+		reported[j.ClientID] = !j.FinishedAt.IsZero()
+	}
+
+	missing := []string{}
+	for _, id := range mj.ClientIDs {
+		if !reported[id] && names[id] == "" {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return names
+	}
+
+	clients, err := jc.Rport.AllClientsWithFields(
+		ctx,
+		api.NewFilters("id", strings.Join(missing, ",")),
+		api.Fields{"id", "name"},
+		nil,
+	)
+	if err != nil {
+		logrus.Warnf("failed to fetch the names of the clients which didn't report back: %v", err)
+		return names
+	}
+	for _, c := range clients {
+		names[c.ID] = c.Name
+	}
+
+	return names
+}
+
+func (jc *JobController) now() time.Time {
+	if jc.Now != nil {
+		return jc.Now()
+	}
+	return time.Now()
+}
+
+// readJobsFilter reads --status, --created-by and the optional --since and --until range
+func readJobsFilter(params *options.ParameterBag, now time.Time) (filter api.JobsFilter, err error) {
+	filter.Status = params.ReadString(config.JobStatus, "")
+	filter.CreatedBy = params.ReadString(config.CreatedBy, "")
+
+	if since := params.ReadString(config.Since, ""); since != "" {
+		filter.Since, err = utils.ParseTimeOrAgo(since, now)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --%s value: %v", config.Since, err)
+		}
+	}
+	if until := params.ReadString(config.Until, ""); until != "" {
+		filter.Until, err = utils.ParseTimeOrAgo(until, now)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --%s value: %v", config.Until, err)
+		}
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return filter, fmt.Errorf("--%s must be before --%s", config.Since, config.Until)
+	}
+
+	return filter, nil
+}
+
+func warnJobsTruncated(truncated bool, shown, total int) {
+	if truncated {
+		logrus.Warnf(
+			"showing %d of %d jobs, use --%s and --%s to see more",
+			shown,
+			total,
+			api.PaginationLimit,
+			api.PaginationOffset,
+		)
+	}
+}
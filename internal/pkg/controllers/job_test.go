@@ -0,0 +1,340 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type JobHistoryRendererMock struct {
+	jobs      []*models.Job
+	multiJobs []*models.MultiJob
+}
+
+func (jhrm *JobHistoryRendererMock) RenderJobs(jobs []*models.Job) error {
+	jhrm.jobs = jobs
+	return nil
+}
+
+func (jhrm *JobHistoryRendererMock) RenderMultiJobs(multiJobs []*models.MultiJob) error {
+	jhrm.multiJobs = multiJobs
+	return nil
+}
+
+type JobsCollectorMock struct {
+	jobs []*models.Job
+}
+
+func (jcm *JobsCollectorMock) RenderJob(j *models.Job) error {
+	jcm.jobs = append(jcm.jobs, j)
+	return nil
+}
+
+func (jcm *JobsCollectorMock) RenderJobStarted(js *models.JobStarted) error {
+	return nil
+}
+
+func (jcm *JobsCollectorMock) Flush() error {
+	return nil
+}
+
+func (jcm *JobsCollectorMock) RenderSummary(es *models.ExecutionSummary) error {
+	return nil
+}
+
+func (jcm *JobsCollectorMock) RenderCommandVariants(variants []*models.CommandVariant) error {
+	return nil
+}
+
+func TestListMultiJobs(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.MultiJobsURL, r.URL.Path)
+		assert.Equal(t, "failed", r.URL.Query().Get("filter[status]"))
+		assert.Equal(t, "admin", r.URL.Query().Get("filter[created_by]"))
+		assert.Equal(t, "2022-02-28T12:00:00Z", r.URL.Query().Get("filter[started_at][gt]"))
+		assert.Equal(t, "", r.URL.Query().Get("filter[started_at][lt]"))
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.MultiJobsResponse{
+			Data: []*models.MultiJob{{Jid: "j1", CreatedBy: "admin", ClientIDs: []string{"1", "2"}}},
+		}))
+	}))
+	defer srv.Close()
+
+	renderer := &JobHistoryRendererMock{}
+	jc := &JobController{
+		Rport:              api.New(srv.URL, nil),
+		JobHistoryRenderer: renderer,
+		Now: func() time.Time {
+			return now
+		},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.JobStatus: "failed",
+		config.CreatedBy: "admin",
+		config.Since:     "1d",
+	})
+	err := jc.List(context.Background(), params)
+	require.NoError(t, err)
+
+	require.Len(t, renderer.multiJobs, 1)
+	assert.Equal(t, "j1", renderer.multiJobs[0].Jid)
+	assert.Nil(t, renderer.jobs)
+}
+
+func TestListClientJobsByName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		jsonEnc := json.NewEncoder(rw)
+		if r.URL.Path == api.ClientsURL {
+			assert.Equal(t, "web1", r.URL.Query().Get("filter[name]"))
+			assert.NoError(t, jsonEnc.Encode(api.ClientsResponse{Data: []*models.Client{{ID: "123", Name: "web1"}}}))
+			return
+		}
+
+		assert.Equal(t, "/api/v1/clients/123/commands", r.URL.Path)
+		assert.Equal(t, "-started_at", r.URL.Query().Get("sort"))
+		assert.NoError(t, jsonEnc.Encode(api.JobsResponse{Data: []*models.Job{{Jid: "j1", Status: "successful"}}}))
+	}))
+	defer srv.Close()
+
+	renderer := &JobHistoryRendererMock{}
+	jc := &JobController{
+		Rport:              api.New(srv.URL, nil),
+		JobHistoryRenderer: renderer,
+	}
+
+	err := jc.List(context.Background(), config.FromValues(map[string]string{config.ClientNameFlag: "web1"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.Job{{Jid: "j1", Status: "successful", ClientID: "123", ClientName: "web1"}}, renderer.jobs)
+}
+
+func TestGetMultiJobAddsClientNames(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		jsonEnc := json.NewEncoder(rw)
+		if r.URL.Path == api.ClientsURL {
+			assert.Equal(t, "2", r.URL.Query().Get("filter[id]"))
+			assert.NoError(t, jsonEnc.Encode(api.ClientsResponse{Data: []*models.Client{{ID: "2", Name: "db1"}}}))
+			return
+		}
+
+		assert.Equal(t, "/api/v1/commands/j1", r.URL.Path)
+		assert.NoError(t, jsonEnc.Encode(api.MultiJobResponse{Data: &models.MultiJob{
+			Jid: "j1",
+			Jobs: []*models.Job{
+				{Jid: "c1", ClientID: "1", ClientName: "web1", MultiJobID: "j1"},
+				{Jid: "c2", ClientID: "2", MultiJobID: "j1"},
+			},
+		}}))
+	}))
+	defer srv.Close()
+
+	renderer := &JobsCollectorMock{}
+	jc := &JobController{
+		Rport:       api.New(srv.URL, nil),
+		JobRenderer: renderer,
+	}
+
+	err := jc.Get(context.Background(), config.FromValues(map[string]string{}), "j1")
+	require.NoError(t, err)
+
+	require.Len(t, renderer.jobs, 2)
+	assert.Equal(t, "web1", renderer.jobs[0].ClientName)
+	assert.Equal(t, "db1", renderer.jobs[1].ClientName)
+}
+
+func TestGetClientJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/clients/123/commands/c1", r.URL.Path)
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.JobResponse{Data: &models.Job{Jid: "c1", ClientName: "web1"}}))
+	}))
+	defer srv.Close()
+
+	renderer := &JobsCollectorMock{}
+	jc := &JobController{
+		Rport:       api.New(srv.URL, nil),
+		JobRenderer: renderer,
+	}
+
+	err := jc.Get(context.Background(), config.FromValues(map[string]string{config.ClientID: "123"}), "c1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.Job{{Jid: "c1", ClientID: "123", ClientName: "web1"}}, renderer.jobs)
+}
+
+func TestReadJobsFilter(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	filter, err := readJobsFilter(config.FromValues(map[string]string{}), now)
+	require.NoError(t, err)
+	assert.Equal(t, api.JobsFilter{}, filter)
+
+	_, err = readJobsFilter(config.FromValues(map[string]string{config.Since: "1h", config.Until: "2h"}), now)
+	assert.EqualError(t, err, "--since must be before --until")
+
+	_, err = readJobsFilter(config.FromValues(map[string]string{config.Until: "yesterday"}), now)
+	assert.Error(t, err)
+}
+
+func TestWaitRendersJobsOnceAndWritesExecLog(t *testing.T) {
+	startedAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(time.Minute)
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/commands/multi-1", r.URL.Path)
+		polls++
+		mj := &models.MultiJob{
+			Jid:       "multi-1",
+			StartedAt: startedAt,
+			ClientIDs: []string{"1", "2"},
+			Jobs: []*models.Job{
+				{Jid: "c1", ClientID: "1", ClientName: "web1", Status: "successful", FinishedAt: finishedAt},
+				{Jid: "c2", ClientID: "2", ClientName: "web2", Status: "running"},
+			},
+		}
+		if polls > 1 {
+			mj.Jobs[1].Status = "failed"
+			mj.Jobs[1].FinishedAt = finishedAt
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.MultiJobResponse{Data: mj}))
+	}))
+	defer srv.Close()
+
+	logFilename := filepath.Join(t.TempDir(), "wait.yaml")
+	renderer := &JobsCollectorMock{}
+	jc := &JobController{
+		Rport:       api.New(srv.URL, nil),
+		JobRenderer: renderer,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.WatchInterval: "1s",
+		config.WriteExecLog:  logFilename,
+	})
+	err := jc.Wait(context.Background(), params, "multi-1", nil, make(chan os.Signal, 1))
+	assertExitCode(t, err, ExitCodeSomeFailed)
+
+	assert.Equal(t, 2, polls)
+	require.Len(t, renderer.jobs, 2)
+	assert.Equal(t, "c1", renderer.jobs[0].Jid)
+	assert.Equal(t, "c2", renderer.jobs[1].Jid)
+
+	logInfo, err := ReadJobsFromYAML(logFilename)
+	require.NoError(t, err)
+	assert.Equal(t, 2, logInfo.NumClients)
+	assert.Equal(t, 1, logInfo.Failed)
+	assert.Len(t, logInfo.Jobs, 2)
+}
+
+func TestWaitTimeoutExpired(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mj := &models.MultiJob{
+			Jid:       "multi-1",
+			ClientIDs: []string{"1"},
+			Jobs:      []*models.Job{{Jid: "c1", ClientID: "1", Status: "running"}},
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.MultiJobResponse{Data: mj}))
+	}))
+	defer srv.Close()
+
+	renderer := &JobsCollectorMock{}
+	jc := &JobController{
+		Rport:       api.New(srv.URL, nil),
+		JobRenderer: renderer,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	params := config.FromValues(map[string]string{config.WatchInterval: "1s"})
+	err := jc.Wait(ctx, params, "multi-1", nil, make(chan os.Signal, 1))
+	assert.EqualError(t, err, "the global --timeout expired before all jobs finished")
+}
+
+func TestUnreportedClientNames(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.ClientsURL, r.URL.Path)
+		assert.Equal(t, "3", r.URL.Query().Get("filter[id]"))
+		clients := []*models.Client{{ID: "3", Name: "db1"}}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.ClientsResponse{Data: clients}))
+	}))
+	defer srv.Close()
+
+	jc := &JobController{Rport: api.New(srv.URL, nil)}
+	mj := &models.MultiJob{
+		ClientIDs: []string{"1", "2", "3"},
+		Jobs: []*models.Job{
+			{ClientID: "1", ClientName: "web1", Status: "successful", FinishedAt: time.Now()},
+			{ClientID: "2", ClientName: "web2", Status: "running"},
+		},
+	}
+
+	names := jc.unreportedClientNames(context.Background(), mj)
+	assert.Equal(t, "web2", names["2"])
+	assert.Equal(t, "db1", names["3"])
+}
+
+func TestMultiJobFinished(t *testing.T) {
+	finishedAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		multiJob *models.MultiJob
+		expected bool
+	}{
+		{
+			name:     "no jobs yet",
+			multiJob: &models.MultiJob{ClientIDs: []string{"1"}},
+			expected: false,
+		},
+		{
+			name: "remaining clients",
+			multiJob: &models.MultiJob{
+				ClientIDs: []string{"1", "2"},
+				Jobs:      []*models.Job{{ClientID: "1", FinishedAt: finishedAt}},
+			},
+			expected: false,
+		},
+		{
+			name: "aborted on error",
+			multiJob: &models.MultiJob{
+				ClientIDs:  []string{"1", "2"},
+				AbortOnErr: true,
+				Jobs:       []*models.Job{{ClientID: "1", Status: "failed", FinishedAt: finishedAt}},
+			},
+			expected: true,
+		},
+		{
+			name: "running",
+			multiJob: &models.MultiJob{
+				ClientIDs: []string{"1"},
+				Jobs:      []*models.Job{{ClientID: "1", Status: "running"}},
+			},
+			expected: false,
+		},
+		{
+			name: "groups only",
+			multiJob: &models.MultiJob{
+				GroupIDs: []string{"g1"},
+				Jobs:     []*models.Job{{ClientID: "1", FinishedAt: finishedAt}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, multiJobFinished(tc.multiJob))
+		})
+	}
+}
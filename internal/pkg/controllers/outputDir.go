@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const outputDirIndexFilename = "index.json"
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// OutputDir writes the stdout, stderr and meta data of every finished job to files named after the client,
+// so large outputs can be searched and compared with the usual command line tools
+type OutputDir struct {
+	dir       string
+	usedNames map[string]bool
+	index     *OutputDirIndex
+	// rowsByClientID lets the job of a retried client replace the files of its previous attempt
+	rowsByClientID map[string]*OutputDirIndexRow
+}
+
+type OutputDirIndex struct {
+	ExecutedAt time.Time            `json:"executed_at"`
+	NumClients int                  `json:"num_clients"`
+	Failed     int                  `json:"failed"`
+	Clients    []*OutputDirIndexRow `json:"clients"`
+}
+
+type OutputDirIndexRow struct {
+	ClientID   string `json:"client_id"`
+	ClientName string `json:"client_name"`
+	Status     string `json:"status"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	Meta       string `json:"meta"`
+}
+
+type JobMeta struct {
+	Jid         string    `json:"jid"`
+	MultiJobID  string    `json:"multi_job_id"`
+	ClientID    string    `json:"client_id"`
+	ClientName  string    `json:"client_name"`
+	Status      string    `json:"status"`
+	Pid         int       `json:"pid"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	DurationSec float64   `json:"duration_sec"`
+	Error       string    `json:"error"`
+}
+
+func NewOutputDir(dir string) (*OutputDir, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputDir{
+		dir:            dir,
+		usedNames:      map[string]bool{},
+		index:          &OutputDirIndex{Clients: []*OutputDirIndexRow{}},
+		rowsByClientID: map[string]*OutputDirIndexRow{},
+	}, nil
+}
+
+// WriteJob writes <client>.stdout, <client>.stderr and <client>.meta.json of a finished job, the files of a previous
+// attempt of the client are overwritten
+func (od *OutputDir) WriteJob(j *models.Job) error {
+	row, isRetry := od.rowsByClientID[j.ClientID]
+	if !isRetry {
+		baseName := od.uniqueBaseName(j)
+		row = &OutputDirIndexRow{
+			ClientID:   j.ClientID,
+			ClientName: j.ClientName,
+			Stdout:     baseName + ".stdout",
+			Stderr:     baseName + ".stderr",
+			Meta:       baseName + ".meta.json",
+		}
+	}
+	row.Status = j.Status
+
+	err := od.writeFile(row.Stdout, []byte(j.Result.Stdout))
+	if err != nil {
+		return err
+	}
+
+	err = od.writeFile(row.Stderr, []byte(j.Result.Stderr))
+	if err != nil {
+		return err
+	}
+
+	meta := &JobMeta{
+		Jid:        j.Jid,
+		MultiJobID: j.MultiJobID,
+		ClientID:   j.ClientID,
+		ClientName: j.ClientName,
+		Status:     j.Status,
+		Pid:        j.Pid,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		Error:      j.Error,
+	}
+	if !j.StartedAt.IsZero() {
+		meta.DurationSec = j.FinishedAt.Sub(j.StartedAt).Seconds()
+	}
+	err = od.writeJSON(row.Meta, meta)
+	if err != nil {
+		return err
+	}
+
+	if !isRetry {
+		od.index.Clients = append(od.index.Clients, row)
+		od.rowsByClientID[j.ClientID] = row
+	}
+
+	return nil
+}
+
+// WriteIndex writes index.json listing the clients of the run with their status and files
+func (od *OutputDir) WriteIndex(executedAt time.Time) error {
+	od.index.ExecutedAt = executedAt
+	od.index.NumClients = len(od.index.Clients)
+	od.index.Failed = 0
+	for _, row := range od.index.Clients {
+		if row.Status == statusFailed {
+			od.index.Failed++
+		}
+	}
+
+	return od.writeJSON(outputDirIndexFilename, od.index)
+}
+
+// uniqueBaseName returns the client name or id usable as a file name, the client id is appended
+// when several clients have the same name
+func (od *OutputDir) uniqueBaseName(j *models.Job) string {
+	name := j.ClientName
+	if name == "" {
+		name = j.ClientID
+	}
+	name = unsafeFilenameChars.ReplaceAllString(name, "_")
+	if od.usedNames[name] {
+		name += "_" + unsafeFilenameChars.ReplaceAllString(j.ClientID, "_")
+	}
+	od.usedNames[name] = true
+
+	return name
+}
+
+func (od *OutputDir) writeJSON(filename string, source interface{}) error {
+	contents, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return od.writeFile(filename, append(contents, '\n'))
+}
+
+func (od *OutputDir) writeFile(filename string, contents []byte) error {
+	return os.WriteFile(filepath.Join(od.dir, filename), contents, 0600)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputDirWriteJobs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "run")
+	startedAt := time.Date(2022, 7, 13, 5, 57, 0, 0, time.UTC)
+
+	od, err := NewOutputDir(dir)
+	require.NoError(t, err)
+
+	jobs := []*models.Job{
+		{
+			Jid:        "j1",
+			ClientID:   "id1",
+			ClientName: "web/01",
+			Status:     "successful",
+			Pid:        12,
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(1500 * time.Millisecond),
+			Result:     models.JobResult{Stdout: "Ubuntu\n"},
+		},
+		{
+			Jid:        "j2",
+			ClientID:   "id2",
+			ClientName: "web/01",
+			Status:     "failed",
+			FinishedAt: startedAt,
+			Error:      "exit status 1",
+			Result:     models.JobResult{Stderr: "no such file\n"},
+		},
+		{
+			Jid:        "j3",
+			ClientID:   "id3",
+			Status:     "successful",
+			FinishedAt: startedAt,
+		},
+	}
+	for _, j := range jobs {
+		require.NoError(t, od.WriteJob(j))
+	}
+	require.NoError(t, od.WriteIndex(startedAt))
+
+	assertFileContent(t, filepath.Join(dir, "web_01.stdout"), "Ubuntu\n")
+	assertFileContent(t, filepath.Join(dir, "web_01.stderr"), "")
+	assertFileContent(t, filepath.Join(dir, "web_01_id2.stderr"), "no such file\n")
+	assertFileContent(t, filepath.Join(dir, "id3.stdout"), "")
+
+	metaJSON, err := os.ReadFile(filepath.Join(dir, "web_01.meta.json"))
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"jid":"j1","multi_job_id":"","client_id":"id1","client_name":"web/01","status":"successful","pid":12,`+
+			`"started_at":"2022-07-13T05:57:00Z","finished_at":"2022-07-13T05:57:01.5Z","duration_sec":1.5,"error":""}`,
+		string(metaJSON),
+	)
+
+	indexJSON, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"executed_at":"2022-07-13T05:57:00Z","num_clients":3,"failed":1,"clients":[
+{"client_id":"id1","client_name":"web/01","status":"successful","stdout":"web_01.stdout","stderr":"web_01.stderr","meta":"web_01.meta.json"},
+{"client_id":"id2","client_name":"web/01","status":"failed","stdout":"web_01_id2.stdout","stderr":"web_01_id2.stderr","meta":"web_01_id2.meta.json"},
+{"client_id":"id3","client_name":"","status":"successful","stdout":"id3.stdout","stderr":"id3.stderr","meta":"id3.meta.json"}]}`,
+		string(indexJSON),
+	)
+}
+
+func TestOutputDirWriteRetriedJob(t *testing.T) {
+	dir := t.TempDir()
+	od, err := NewOutputDir(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, od.WriteJob(&models.Job{
+		ClientID:   "id1",
+		ClientName: "web01",
+		Status:     "failed",
+		Result:     models.JobResult{Stderr: "locked\n"},
+		Attempt:    1,
+	}))
+	require.NoError(t, od.WriteJob(&models.Job{
+		ClientID:   "id1",
+		ClientName: "web01",
+		Status:     "successful",
+		Result:     models.JobResult{Stdout: "done\n"},
+		Attempt:    2,
+	}))
+	require.NoError(t, od.WriteIndex(time.Date(2022, 7, 13, 5, 57, 0, 0, time.UTC)))
+
+	assertFileContent(t, filepath.Join(dir, "web01.stdout"), "done\n")
+	assertFileContent(t, filepath.Join(dir, "web01.stderr"), "")
+
+	index := &OutputDirIndex{}
+	indexJSON, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(indexJSON, index))
+	assert.Equal(t, 1, index.NumClients)
+	assert.Equal(t, 0, index.Failed)
+	require.Len(t, index.Clients, 1)
+	assert.Equal(t, "successful", index.Clients[0].Status)
+}
+
+func TestCommandExecutionWithOutputDir(t *testing.T) {
+	eh, jobResp := makeExecutionHelperWithSimpleJob(t)
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+	dir := t.TempDir()
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "1235",
+		config.Command:   "cmd",
+		config.OutputDir: dir,
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeAllFailed)
+
+	assertFileContent(t, filepath.Join(dir, jobResp.ClientName+".stdout"), jobResp.Result.Stdout)
+
+	index := &OutputDirIndex{}
+	indexJSON, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(indexJSON, index))
+	assert.Equal(t, 1, index.NumClients)
+	assert.Equal(t, 1, index.Failed)
+}
+
+func TestCommandExecutionDetachedWithOutputDir(t *testing.T) {
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "1235",
+		config.Command:   "cmd",
+		config.Detach:    "1",
+		config.OutputDir: "out",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assert.EqualError(t, err, "--output-dir can't be used with --detach")
+}
+
+func assertFileContent(t *testing.T, filename, expected string) {
+	t.Helper()
+
+	actual, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	io2 "github.com/breathbath/go_utils/v2/pkg/io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+const (
+	ReportFormatJUnit = "junit"
+	ReportFormatTAP   = "tap"
+)
+
+// Report is a file the results of an execution are written to in a format understood by CI systems
+type Report struct {
+	Format   string
+	Filename string
+}
+
+// ParseReports parses the FORMAT=FILE values of --report
+func ParseReports(values []string) ([]*Report, error) {
+	reports := make([]*Report, 0, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid --%s %q, expected %s=FILE or %s=FILE", config.Report, value, ReportFormatJUnit, ReportFormatTAP)
+		}
+
+		format := strings.ToLower(strings.TrimSpace(parts[0]))
+		if format != ReportFormatJUnit && format != ReportFormatTAP {
+			return nil, fmt.Errorf("unknown report format %q, use %s or %s", parts[0], ReportFormatJUnit, ReportFormatTAP)
+		}
+
+		reports = append(reports, &Report{Format: format, Filename: strings.TrimSpace(parts[1])})
+	}
+
+	return reports, nil
+}
+
+// WriteReports writes the job of each client in the execution log as a test case named after the client to the
+// report files, of retried jobs only the last attempt is written
+func WriteReports(reports []*Report, logInfo *ExecutionLogInfo) error {
+	finalLogInfo := *logInfo
+	finalLogInfo.Jobs = finalJobs(logInfo.Jobs)
+	for _, r := range reports {
+		err := writeReport(r, &finalLogInfo)
+		if err != nil {
+			return fmt.Errorf("failed to write %s report %s: %v", r.Format, r.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+func writeReport(r *Report, logInfo *ExecutionLogInfo) error {
+	f, err := os.OpenFile(r.Filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer io2.CloseResourceSecure(r.Filename, f)
+
+	if r.Format == ReportFormatTAP {
+		return output.RenderTAPReport(f, logInfo.Jobs)
+	}
+
+	return output.RenderJUnitReport(f, logInfo.ExecutedAt, logInfo.Jobs)
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReports(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Values          []string
+		ExpectedReports []*Report
+		ExpectedError   string
+	}{
+		{
+			Name:            "no reports",
+			ExpectedReports: []*Report{},
+		},
+		{
+			Name:   "junit and tap",
+			Values: []string{"junit=out/report.xml", " TAP = report.tap"},
+			ExpectedReports: []*Report{
+				{Format: ReportFormatJUnit, Filename: "out/report.xml"},
+				{Format: ReportFormatTAP, Filename: "report.tap"},
+			},
+		},
+		{
+			Name:          "missing file",
+			Values:        []string{"junit="},
+			ExpectedError: `invalid --report "junit=", expected junit=FILE or tap=FILE`,
+		},
+		{
+			Name:          "unknown format",
+			Values:        []string{"html=report.html"},
+			ExpectedError: `unknown report format "html", use junit or tap`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			reports, err := ParseReports(tc.Values)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedReports, reports)
+		})
+	}
+}
+
+func TestExecLogConvert(t *testing.T) {
+	dir := t.TempDir()
+	junitFile := filepath.Join(dir, "report.xml")
+	tapFile := filepath.Join(dir, "report.tap")
+
+	elc := &ExecLogController{}
+	err := elc.Convert("../../../testdata/execlog-3jobs1failed.yaml", []string{"junit=" + junitFile, "tap=" + tapFile})
+	require.NoError(t, err)
+
+	junit, err := os.ReadFile(junitFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(junit), `<testsuite name="pwd" id="8644eeca-0efa-474e-bb2c-6d39e238508b" tests="3" failures="1"`)
+	assert.Contains(t, string(junit), `<failure message="job failed" type="failed">client error: command is not allowed: pwd</failure>`)
+
+	tap, err := os.ReadFile(tapFile)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(tap), "TAP version 13\n1..3\nnot ok 1 - ITXC\n"))
+	assert.Contains(t, string(tap), "ok 2 - Kathy-Daniels\n")
+}
+
+func TestExecLogConvertWithoutReport(t *testing.T) {
+	elc := &ExecLogController{}
+	err := elc.Convert("../../../testdata/execlog-3jobs1failed.yaml", nil)
+	assert.EqualError(t, err, "no report requested, use --report junit=FILE or --report tap=FILE")
+}
+
+func TestCommandExecutionWithReport(t *testing.T) {
+	eh, jobResp := makeExecutionHelperWithSimpleJob(t)
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+	tapFile := filepath.Join(t.TempDir(), "report.tap")
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "1235",
+		config.Command:   "cmd",
+		config.Report:    "tap=" + tapFile,
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeAllFailed)
+
+	tap, err := os.ReadFile(tapFile)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(tap), "TAP version 13\n1..1\nnot ok 1 - "+jobResp.ClientName+"\n"))
+}
+
+func TestCommandExecutionDetachedWithReport(t *testing.T) {
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "1235",
+		config.Command:   "cmd",
+		config.Detach:    "1",
+		config.Report:    "junit=report.xml",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assert.EqualError(t, err, "--report can't be used with --detach, use --write-execlog with 'job wait' and 'execlog convert' instead")
+}
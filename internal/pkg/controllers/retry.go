@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+// RetryPolicy tells how often the command is executed again on the clients it has failed on and how long
+// to wait before, the delay is multiplied by the backoff factor after each retry
+type RetryPolicy struct {
+	Retries int
+	Delay   time.Duration
+	Backoff float64
+}
+
+func readRetryPolicy(params *options.ParameterBag) (*RetryPolicy, error) {
+	rp := &RetryPolicy{
+		Retries: params.ReadInt(config.Retries, 0),
+	}
+	if rp.Retries < 0 {
+		return nil, fmt.Errorf("--%s must not be negative", config.Retries)
+	}
+
+	delay := params.ReadString(config.RetryDelay, config.DefaultRetryDelay)
+	var err error
+	rp.Delay, err = time.ParseDuration(delay)
+	if err != nil || rp.Delay < 0 {
+		return nil, fmt.Errorf("invalid --%s %q, expected a duration like 30s or 2m", config.RetryDelay, delay)
+	}
+
+	backoff := params.ReadString(config.RetryBackoff, config.DefaultRetryBackoff)
+	rp.Backoff, err = strconv.ParseFloat(backoff, 64)
+	if err != nil || rp.Backoff < 1 {
+		return nil, fmt.Errorf("invalid --%s %q, expected a factor of 1 or more", config.RetryBackoff, backoff)
+	}
+
+	return rp, nil
+}
+
+// DelayBefore returns the time to wait before the given retry, the first retry is 1
+func (rp *RetryPolicy) DelayBefore(retry int) time.Duration {
+	return time.Duration(float64(rp.Delay) * math.Pow(rp.Backoff, float64(retry-1)))
+}
+
+// attempt returns the attempt stored with the jobs of the given retry, the first execution is retry 0,
+// without retries the attempt is 0
+func (rp *RetryPolicy) attempt(retries int) int {
+	if rp.Retries == 0 {
+		return 0
+	}
+	return retries + 1
+}
+
+// waitInterruptibly waits before the next retry or batch, interrupted tells if the wait was stopped by a signal,
+// an ended context is returned as error
+func waitInterruptibly(ctx context.Context, delay time.Duration) (interrupted bool, err error) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return false, nil
+	case <-sigs:
+		return true, nil
+	case <-ctx.Done():
+		return false, contextError(ctx)
+	}
+}
+
+// failedClientIDs returns the clients the execution has failed on, which are the clients with a job which failed
+// or timed out in the order of the jobs followed by the targeted clients which didn't report back,
+// the same clients are retried, counted for the exit code and checked to stop the rollout
+func failedClientIDs(targets []string, jobs []*models.Job) []string {
+	clientIDs := make([]string, 0)
+	for _, j := range jobs {
+		if j.Status == statusFailed || j.Status == statusTimedOut {
+			clientIDs = append(clientIDs, j.ClientID)
+		}
+	}
+
+	return append(clientIDs, unreportedClientIDs(targets, jobs)...)
+}
+
+// unreportedClientIDs returns the targeted clients without a job in the order of the targets
+func unreportedClientIDs(targets []string, jobs []*models.Job) []string {
+	reported := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		reported[j.ClientID] = true
+	}
+
+	clientIDs := make([]string, 0)
+	for _, id := range targets {
+		if !reported[id] {
+			clientIDs = append(clientIDs, id)
+		}
+	}
+
+	return clientIDs
+}
+
+// finalJobs returns the last job of each client, which is the job of the last attempt if failed jobs were retried,
+// the clients are ordered by their first job
+func finalJobs(jobs []*models.Job) []*models.Job {
+	final := make([]*models.Job, 0, len(jobs))
+	indexByClientID := make(map[string]int, len(jobs))
+	for _, j := range jobs {
+		if i, ok := indexByClientID[j.ClientID]; ok {
+			final[i] = j
+			continue
+		}
+		indexByClientID[j.ClientID] = len(final)
+		final = append(final, j)
+	}
+	return final
+}
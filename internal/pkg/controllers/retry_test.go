@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Params         map[string]string
+		ExpectedPolicy *RetryPolicy
+		ExpectedError  string
+	}{
+		{
+			Name:           "defaults",
+			ExpectedPolicy: &RetryPolicy{Delay: 10 * time.Second, Backoff: 1},
+		},
+		{
+			Name: "retries with backoff",
+			Params: map[string]string{
+				config.Retries:      "3",
+				config.RetryDelay:   "30s",
+				config.RetryBackoff: "2",
+			},
+			ExpectedPolicy: &RetryPolicy{Retries: 3, Delay: 30 * time.Second, Backoff: 2},
+		},
+		{
+			Name:          "negative retries",
+			Params:        map[string]string{config.Retries: "-1"},
+			ExpectedError: "--retries must not be negative",
+		},
+		{
+			Name:          "invalid delay",
+			Params:        map[string]string{config.RetryDelay: "30"},
+			ExpectedError: `invalid --retry-delay "30", expected a duration like 30s or 2m`,
+		},
+		{
+			Name:          "backoff below 1",
+			Params:        map[string]string{config.RetryBackoff: "0.5"},
+			ExpectedError: `invalid --retry-backoff "0.5", expected a factor of 1 or more`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rp, err := readRetryPolicy(config.FromValues(tc.Params))
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedPolicy, rp)
+		})
+	}
+}
+
+func TestRetryPolicyDelayBefore(t *testing.T) {
+	rp := &RetryPolicy{Retries: 3, Delay: 30 * time.Second, Backoff: 2}
+
+	assert.Equal(t, 30*time.Second, rp.DelayBefore(1))
+	assert.Equal(t, time.Minute, rp.DelayBefore(2))
+	assert.Equal(t, 2*time.Minute, rp.DelayBefore(3))
+}
+
+func TestWaitInterruptiblyTimeoutExpired(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	interrupted, err := waitInterruptibly(ctx, time.Minute)
+	assert.False(t, interrupted)
+	assert.EqualError(t, err, "the global --timeout expired before all jobs finished")
+}
+
+func TestFinalJobs(t *testing.T) {
+	failed1 := &models.Job{ClientID: "cl1", Status: models.JobStatusFailed, Attempt: 1}
+	successful2 := &models.Job{ClientID: "cl2", Status: models.JobStatusSuccessful, Attempt: 1}
+	successful1 := &models.Job{ClientID: "cl1", Status: models.JobStatusSuccessful, Attempt: 2}
+
+	assert.Equal(t, []*models.Job{successful1, successful2}, finalJobs([]*models.Job{failed1, successful2, successful1}))
+}
+
+func TestCommandExecutionWithRetries(t *testing.T) {
+	failed := makeSimpleJob(t)
+	successful := makeSimpleJob(t)
+	successful.ClientID = "8444b1c8cad84877931e6277ab3c6bb1"
+	successful.ClientName = "Kathy-Phillips"
+	successful.Status = models.JobStatusSuccessful
+	retried := makeSimpleJob(t)
+	retried.Status = models.JobStatusSuccessful
+
+	firstRW := makeReadWriterMockFromJobs(t, []*models.Job{failed, successful})
+	retryRW := makeReadWriterMockFromJobs(t, []*models.Job{retried})
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  firstRW,
+		JobRenderer: jr,
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			return retryRW, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+	execLogFile := filepath.Join(t.TempDir(), "run.yaml")
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:    failed.ClientID + "," + successful.ClientID,
+		config.Command:      "pwd",
+		config.Retries:      "2",
+		config.RetryDelay:   "0s",
+		config.WriteExecLog: execLogFile,
+	})
+	err := cc.Start(context.Background(), params, nil, makeBasicTestHostInfo(t))
+	require.NoError(t, err)
+
+	assert.True(t, firstRW.isClosed)
+	assert.True(t, retryRW.isClosed)
+	require.Len(t, retryRW.writtenItems, 1)
+	retryCmd := &models.WsScriptCommand{}
+	require.NoError(t, json.Unmarshal([]byte(retryRW.writtenItems[0]), retryCmd))
+	assert.Equal(t, []string{failed.ClientID}, retryCmd.ClientIDs)
+
+	require.Len(t, eh.ExecutionResults, 3)
+	assert.Equal(t, []int{1, 1, 2}, []int{
+		eh.ExecutionResults[0].Attempt,
+		eh.ExecutionResults[1].Attempt,
+		eh.ExecutionResults[2].Attempt,
+	})
+
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, 2, jr.summaryToRender.Succeeded)
+	assert.Equal(t, 0, jr.summaryToRender.Failed)
+
+	logInfo, err := ReadJobsFromYAML(execLogFile)
+	require.NoError(t, err)
+	assert.Len(t, logInfo.Jobs, 3)
+	assert.Equal(t, 2, logInfo.NumClients)
+	assert.Equal(t, 0, logInfo.Failed)
+}
+
+func TestFailedClientIDs(t *testing.T) {
+	jobs := makeBatchJobs(t, models.JobStatusSuccessful, "cl1")
+	jobs = append(jobs, makeBatchJobs(t, models.JobStatusUnknown, "cl2")...)
+	jobs = append(jobs, makeBatchJobs(t, models.JobStatusFailed, "cl3")...)
+
+	assert.Equal(t, []string{"cl2", "cl3", "cl4"}, failedClientIDs([]string{"cl1", "cl2", "cl3", "cl4"}, jobs))
+}
+
+func TestCommandExecutionRetriesTimedOutAndUnreportedClients(t *testing.T) {
+	retryRW := makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl1", "cl2"))
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusUnknown, "cl1")),
+		JobRenderer: jr,
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			return retryRW, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:  "cl1,cl2",
+		config.Command:    "pwd",
+		config.Retries:    "1",
+		config.RetryDelay: "0s",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, retryRW.writtenItems, 1)
+	retryCmd := &models.WsScriptCommand{}
+	require.NoError(t, json.Unmarshal([]byte(retryRW.writtenItems[0]), retryCmd))
+	assert.Equal(t, []string{"cl1", "cl2"}, retryCmd.ClientIDs)
+
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, 2, jr.summaryToRender.Succeeded)
+}
+
+func TestCommandExecutionWithRetriesExhausted(t *testing.T) {
+	attempts := 0
+	eh := &ExecutionHelper{
+		ReadWriter:  makeReadWriterMockFromJobs(t, []*models.Job{makeSimpleJob(t)}),
+		JobRenderer: &JobRendererMock{},
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			attempts++
+			return makeReadWriterMockFromJobs(t, []*models.Job{makeSimpleJob(t)}), nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:  "76376f704ab0429eb6cb141e6f34ed75",
+		config.Command:    "pwd",
+		config.Retries:    "2",
+		config.RetryDelay: "0s",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeAllFailed)
+	assert.Equal(t, 2, attempts)
+	assert.Len(t, eh.ExecutionResults, 3)
+}
+
+func TestCommandExecutionDetachedWithRetries(t *testing.T) {
+	cc := &CommandsController{
+		ExecutionHelper: &ExecutionHelper{},
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "1235",
+		config.Command:   "cmd",
+		config.Detach:    "1",
+		config.Retries:   "2",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assert.EqualError(t, err, "--retries can't be used with --detach")
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+// Rollout splits the targeted clients into batches executed one after the other, the canary clients form the
+// first batch. A nil BatchSize puts all remaining clients into one batch, a nil MaxFailureRate never stops the rollout.
+type Rollout struct {
+	BatchSize      *utils.Threshold
+	Canary         int
+	PauseBetween   time.Duration
+	MaxFailureRate *utils.Threshold
+}
+
+// rolloutResult tells which clients the command was sent to and why the rollout stopped before the last batch
+type rolloutResult struct {
+	executedClientIDs []string
+	stopReason        string
+	skippedClients    int
+	interrupted       bool
+}
+
+func readRollout(params *options.ParameterBag) (*Rollout, error) {
+	r := &Rollout{
+		Canary: params.ReadInt(config.Canary, 0),
+	}
+	if r.Canary < 0 {
+		return nil, fmt.Errorf("--%s must not be negative", config.Canary)
+	}
+
+	var err error
+	if batchSize := params.ReadString(config.BatchSize, ""); batchSize != "" {
+		r.BatchSize, err = utils.ParseThreshold(batchSize)
+		if err != nil || r.BatchSize.Count(1) < 1 {
+			return nil, fmt.Errorf(
+				"invalid --%s %q, expected a number of clients like 10 or a percentage like 10%%",
+				config.BatchSize,
+				batchSize,
+			)
+		}
+	}
+
+	pause := params.ReadString(config.PauseBetween, "0s")
+	r.PauseBetween, err = time.ParseDuration(pause)
+	if err != nil || r.PauseBetween < 0 {
+		return nil, fmt.Errorf("invalid --%s %q, expected a duration like 60s or 5m", config.PauseBetween, pause)
+	}
+
+	if maxFailureRate := params.ReadString(config.MaxFailureRate, ""); maxFailureRate != "" {
+		r.MaxFailureRate, err = utils.ParseThreshold(maxFailureRate)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %v", config.MaxFailureRate, err)
+		}
+	}
+
+	if r.IsBatched() && params.ReadString(config.GroupIDs, "") != "" {
+		return nil, fmt.Errorf("--%s and --%s can't be used with --%s", config.BatchSize, config.Canary, config.GroupIDs)
+	}
+
+	return r, nil
+}
+
+// IsBatched tells if the clients are split into several batches
+func (r *Rollout) IsBatched() bool {
+	return r.Canary > 0 || r.BatchSize != nil
+}
+
+// Batches splits the clients into the canary batch followed by batches of the batch size,
+// a percentage batch size is taken of all clients
+func (r *Rollout) Batches(clientIDs []string) [][]string {
+	batches := make([][]string, 0)
+	rest := clientIDs
+	if r.Canary > 0 && r.Canary < len(rest) {
+		batches = append(batches, rest[:r.Canary])
+		rest = rest[r.Canary:]
+	}
+
+	size := len(rest)
+	if r.BatchSize != nil {
+		size = r.BatchSize.Count(len(clientIDs))
+	}
+	for len(rest) > 0 {
+		if size > len(rest) {
+			size = len(rest)
+		}
+		batches = append(batches, rest[:size])
+		rest = rest[size:]
+	}
+
+	return batches
+}
+
+// stopReason tells why no further batch is started, any failure stops the rollout after the canary batch,
+// after the other batches the failures of all batches so far are checked against the max failure rate,
+// jobs which failed or timed out and clients which didn't report back count as failure
+func (r *Rollout) stopReason(isCanary bool, batch []string, batchJobs []*models.Job, executed []string, allJobs []*models.Job) string {
+	if isCanary {
+		if failed, total := countFailures(batch, batchJobs); failed > 0 {
+			return fmt.Sprintf("the canary execution failed on %d of %d clients", failed, total)
+		}
+		return ""
+	}
+
+	failed, total := countFailures(executed, allJobs)
+	if r.MaxFailureRate != nil && r.MaxFailureRate.Exceeded(failed, total) {
+		return fmt.Sprintf(
+			"failed on %d of %d clients, which exceeds --%s %s",
+			failed,
+			total,
+			config.MaxFailureRate,
+			r.MaxFailureRate,
+		)
+	}
+
+	return ""
+}
+
+// runBatches executes the command on one batch of clients after the other, failed jobs are retried within
+// their batch, the rollout stops before the next batch if the canary or too many jobs failed,
+// the clients of a batch get one command per variant rendered by --template, see run for how the variants are executed
+func (eh *ExecutionHelper) runBatches(
+	ctx context.Context,
+	wsCmd *models.WsScriptCommand,
+	variants []*models.CommandVariant,
+	opts *executeOptions,
+) (*rolloutResult, error) {
+	res := &rolloutResult{executedClientIDs: make([]string, 0, len(wsCmd.ClientIDs))}
+	batches := [][]string{wsCmd.ClientIDs}
+	if opts.rollout.IsBatched() {
+		batches = opts.rollout.Batches(wsCmd.ClientIDs)
+	}
+	for i, batch := range batches {
+		if i > 0 {
+			interrupted, err := waitInterruptibly(ctx, opts.rollout.PauseBetween)
+			if interrupted || err != nil {
+				res.interrupted = interrupted
+				return res, err
+			}
+		}
+
+		isCanary := i == 0 && opts.rollout.Canary > 0 && len(batches) > 1
+		if opts.rollout.IsBatched() {
+			logrus.Infof("executing batch %d of %d on %d clients%s", i+1, len(batches), len(batch), canaryLabel(isCanary))
+		}
+
+		batchStart := len(eh.ExecutionResults)
+		res.executedClientIDs = append(res.executedClientIDs, batch...)
+		interrupted, err := eh.run(ctx, batchCommands(wsCmd, batch, variants), opts.retry)
+		if interrupted || err != nil {
+			res.interrupted = interrupted
+			return res, err
+		}
+
+		if i == len(batches)-1 {
+			break
+		}
+		res.stopReason = opts.rollout.stopReason(
+			isCanary,
+			batch,
+			finalJobs(eh.ExecutionResults[batchStart:]),
+			res.executedClientIDs,
+			finalJobs(eh.ExecutionResults),
+		)
+		if res.stopReason != "" {
+			res.skippedClients = len(wsCmd.ClientIDs) - len(res.executedClientIDs)
+			return res, nil
+		}
+	}
+
+	return res, nil
+}
+
+func canaryLabel(isCanary bool) string {
+	if isCanary {
+		return " (canary)"
+	}
+	return ""
+}
@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutBatches(t *testing.T) {
+	clientIDs := []string{"cl1", "cl2", "cl3", "cl4", "cl5", "cl6", "cl7"}
+
+	testCases := []struct {
+		Name            string
+		Params          map[string]string
+		ExpectedBatches [][]string
+	}{
+		{
+			Name:            "not batched",
+			ExpectedBatches: [][]string{clientIDs},
+		},
+		{
+			Name:            "batch size",
+			Params:          map[string]string{config.BatchSize: "3"},
+			ExpectedBatches: [][]string{{"cl1", "cl2", "cl3"}, {"cl4", "cl5", "cl6"}, {"cl7"}},
+		},
+		{
+			Name:            "batch size percentage",
+			Params:          map[string]string{config.BatchSize: "50%"},
+			ExpectedBatches: [][]string{{"cl1", "cl2", "cl3", "cl4"}, {"cl5", "cl6", "cl7"}},
+		},
+		{
+			Name:            "canary",
+			Params:          map[string]string{config.Canary: "1"},
+			ExpectedBatches: [][]string{{"cl1"}, {"cl2", "cl3", "cl4", "cl5", "cl6", "cl7"}},
+		},
+		{
+			Name:            "canary and batch size",
+			Params:          map[string]string{config.Canary: "2", config.BatchSize: "4"},
+			ExpectedBatches: [][]string{{"cl1", "cl2"}, {"cl3", "cl4", "cl5", "cl6"}, {"cl7"}},
+		},
+		{
+			Name:            "canary with all clients",
+			Params:          map[string]string{config.Canary: "10"},
+			ExpectedBatches: [][]string{clientIDs},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			r, err := readRollout(config.FromValues(tc.Params))
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedBatches, r.Batches(clientIDs))
+		})
+	}
+}
+
+func TestReadInvalidRollout(t *testing.T) {
+	testCases := []struct {
+		Params        map[string]string
+		ExpectedError string
+	}{
+		{
+			Params:        map[string]string{config.BatchSize: "0"},
+			ExpectedError: `invalid --batch-size "0", expected a number of clients like 10 or a percentage like 10%`,
+		},
+		{
+			Params:        map[string]string{config.Canary: "-1"},
+			ExpectedError: "--canary must not be negative",
+		},
+		{
+			Params:        map[string]string{config.PauseBetween: "1"},
+			ExpectedError: `invalid --pause-between "1", expected a duration like 60s or 5m`,
+		},
+		{
+			Params:        map[string]string{config.MaxFailureRate: "120%"},
+			ExpectedError: `--max-failure-rate: invalid threshold "120%": expected a percentage between 0% and 100%`,
+		},
+		{
+			Params:        map[string]string{config.BatchSize: "10", config.GroupIDs: "g1"},
+			ExpectedError: "--batch-size and --canary can't be used with --gids",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := readRollout(config.FromValues(tc.Params))
+		assert.EqualError(t, err, tc.ExpectedError)
+	}
+}
+
+func TestRolloutStopReason(t *testing.T) {
+	successful := makeBatchJobs(t, models.JobStatusSuccessful, "cl1", "cl2", "cl3")
+	failed := makeBatchJobs(t, models.JobStatusFailed, "cl4")
+	timedOut := makeBatchJobs(t, models.JobStatusUnknown, "cl4")
+	cl4 := []string{"cl4"}
+	all := []string{"cl1", "cl2", "cl3", "cl4"}
+
+	r := &Rollout{Canary: 1}
+	assert.Equal(t, "", r.stopReason(true, []string{"cl1"}, successful[:1], []string{"cl1"}, successful[:1]))
+	assert.Equal(t, "the canary execution failed on 1 of 1 clients", r.stopReason(true, cl4, failed, cl4, failed))
+	assert.Equal(t, "the canary execution failed on 1 of 1 clients", r.stopReason(true, cl4, timedOut, cl4, timedOut))
+	assert.Equal(t, "the canary execution failed on 1 of 1 clients", r.stopReason(true, cl4, nil, cl4, nil))
+	assert.Equal(t, "", r.stopReason(false, cl4, failed, all, append(successful, failed...)))
+
+	r, err := readRollout(config.FromValues(map[string]string{config.BatchSize: "2", config.MaxFailureRate: "25%"}))
+	require.NoError(t, err)
+	assert.Equal(t, "", r.stopReason(false, cl4, failed, all, append(successful, failed...)))
+	assert.Equal(
+		t,
+		"failed on 2 of 4 clients, which exceeds --max-failure-rate 25%",
+		r.stopReason(false, cl4, timedOut, all, append(successful[:2], timedOut...)),
+	)
+}
+
+// makeBatchJobs returns a job of the given status for each client
+func makeBatchJobs(t *testing.T, status string, clientIDs ...string) []*models.Job {
+	jobs := make([]*models.Job, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		j := makeSimpleJob(t)
+		j.ClientID = clientID
+		j.ClientName = ""
+		j.Status = status
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+func TestCommandExecutionInBatches(t *testing.T) {
+	firstRW := makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl1"))
+	nextRWs := []*ReadWriterMock{
+		makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl2", "cl3")),
+		makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusFailed, "cl4")),
+	}
+	connections := 0
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  firstRW,
+		JobRenderer: jr,
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			rw := nextRWs[connections]
+			connections++
+			return rw, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:    "cl1,cl2,cl3,cl4",
+		config.Command:      "pwd",
+		config.Canary:       "1",
+		config.BatchSize:    "2",
+		config.PauseBetween: "0s",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeSomeFailed)
+
+	assert.Equal(t, 2, connections)
+	for i, rw := range append([]*ReadWriterMock{firstRW}, nextRWs...) {
+		require.Len(t, rw.writtenItems, 1)
+		wsCmd := &models.WsScriptCommand{}
+		require.NoError(t, json.Unmarshal([]byte(rw.writtenItems[0]), wsCmd))
+		assert.Equal(t, [][]string{{"cl1"}, {"cl2", "cl3"}, {"cl4"}}[i], wsCmd.ClientIDs)
+	}
+
+	assert.Len(t, eh.ExecutionResults, 4)
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, 4, jr.summaryToRender.Targeted)
+	assert.Equal(t, 1, jr.summaryToRender.Failed)
+}
+
+func TestCommandExecutionStoppedByCanary(t *testing.T) {
+	eh := &ExecutionHelper{
+		ReadWriter:  makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusFailed, "cl1")),
+		JobRenderer: &JobRendererMock{},
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			t.Fatal("no further batch expected after the failed canary")
+			return nil, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:     "cl1,cl2,cl3",
+		config.Command:       "pwd",
+		config.Canary:        "1",
+		config.FailThreshold: "100%",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeRolloutStopped)
+	assert.EqualError(
+		t,
+		err,
+		"rollout stopped, the canary execution failed on 1 of 1 clients, not executed on the remaining 2 clients",
+	)
+}
+
+func TestCommandExecutionStoppedByTimedOutCanary(t *testing.T) {
+	eh := &ExecutionHelper{
+		ReadWriter:  makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusUnknown, "cl1")),
+		JobRenderer: &JobRendererMock{},
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			t.Fatal("no further batch expected after the timed out canary")
+			return nil, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:     "cl1,cl2,cl3",
+		config.Command:       "pwd",
+		config.Canary:        "1",
+		config.FailThreshold: "100%",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeRolloutStopped)
+	assert.EqualError(
+		t,
+		err,
+		"rollout stopped, the canary execution failed on 1 of 1 clients, not executed on the remaining 2 clients",
+	)
+}
+
+func TestCommandExecutionStoppedByMaxFailureRate(t *testing.T) {
+	eh := &ExecutionHelper{
+		ReadWriter: makeReadWriterMockFromJobs(t, append(
+			makeBatchJobs(t, models.JobStatusSuccessful, "cl1"),
+			makeBatchJobs(t, models.JobStatusFailed, "cl2")...,
+		)),
+		JobRenderer: &JobRendererMock{},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:      "cl1,cl2,cl3,cl4",
+		config.Command:        "pwd",
+		config.BatchSize:      "50%",
+		config.MaxFailureRate: "10%",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeRolloutStopped)
+	assert.EqualError(
+		t,
+		err,
+		"rollout stopped, failed on 1 of 2 clients, which exceeds --max-failure-rate 10%, not executed on the remaining 2 clients",
+	)
+}
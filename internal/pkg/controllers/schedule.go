@@ -0,0 +1,374 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
+)
+
+const proceedWithScheduleMsg = "Submit the schedule with the above fire times (y/n): "
+
+var ErrScheduleNotConfirmed = errors.New("schedule not confirmed")
+
+type ScheduleRenderer interface {
+	RenderSchedules(schedules []*models.Schedule) error
+	RenderSchedule(s *models.Schedule) error
+	RenderFireTimes(times []time.Time) error
+	RenderDelete(s output.KvProvider) error
+}
+
+type ScheduleController struct {
+	Rport            *api.Rport
+	ScheduleRenderer ScheduleRenderer
+	JobRenderer      JobRenderer
+	PromptReader     config.PromptReader
+	// FlagsChecker tells if flags with a default value like --timeout or --is_sudo were given to update them
+	FlagsChecker config.UsedFlagsChecker
+	Now          func() time.Time
+}
+
+func (sc *ScheduleController) List(ctx context.Context, params *options.ParameterBag) error {
+	pagination := api.NewPaginationFromParams(params)
+	resp, err := sc.Rport.Schedules(ctx, pagination, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.Truncated(pagination) {
+		logrus.Warnf(
+			"showing %d of %d schedules, use --%s and --%s to see more",
+			len(resp.Data),
+			resp.Meta.Count,
+			api.PaginationLimit,
+			api.PaginationOffset,
+		)
+	}
+
+	return sc.ScheduleRenderer.RenderSchedules(resp.Data)
+}
+
+func (sc *ScheduleController) Get(ctx context.Context, ref string) error {
+	s, err := findSchedule(ctx, sc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	return sc.ScheduleRenderer.RenderSchedule(s)
+}
+
+// Create validates the cron expression, shows its next fire times and submits the command with its targets
+// and execution parameters as a new schedule. Clients given by name or search are resolved to their ids once.
+func (sc *ScheduleController) Create(ctx context.Context, params *options.ParameterBag, name string) error {
+	if name == "" {
+		return errors.New("no name provided")
+	}
+
+	cron := params.ReadString(config.Cron, "")
+	cs, err := utils.ParseCron(cron)
+	if err != nil {
+		return err
+	}
+
+	command, interpreter, err := readScheduleCommand(ctx, sc.Rport, params)
+	if err != nil {
+		return err
+	}
+
+	s := &models.Schedule{
+		Name:                name,
+		Schedule:            cron,
+		Type:                models.ScheduleTypeCommand,
+		Command:             command,
+		Interpreter:         interpreter,
+		Cwd:                 params.ReadString(config.Cwd, ""),
+		IsSudo:              params.ReadBool(config.IsSudo, false),
+		TimeoutSec:          params.ReadInt(config.Timeout, config.DefaultCmdTimeoutSeconds),
+		ExecuteConcurrently: params.ReadBool(config.ExecConcurrently, false),
+		AbortOnError:        params.ReadBool(config.AbortOnError, false),
+	}
+	err = sc.readTargets(ctx, params, s)
+	if err != nil {
+		return err
+	}
+
+	err = sc.confirmFireTimes(params, cs)
+	if err != nil {
+		return err
+	}
+
+	s, err = sc.Rport.CreateSchedule(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	return sc.ScheduleRenderer.RenderSchedule(s)
+}
+
+// Update changes only the values given by the flags and keeps the other ones, the targets are replaced
+// if any of the targeting flags is given
+func (sc *ScheduleController) Update(ctx context.Context, params *options.ParameterBag, ref string) error {
+	s, err := findSchedule(ctx, sc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	var cs *utils.CronSchedule
+	if cron := params.ReadString(config.Cron, ""); cron != "" {
+		cs, err = utils.ParseCron(cron)
+		if err != nil {
+			return err
+		}
+		s.Schedule = cron
+		changed = true
+	}
+
+	for field, target := range map[string]*string{
+		config.NewName:     &s.Name,
+		config.Command:     &s.Command,
+		config.Interpreter: &s.Interpreter,
+		config.Cwd:         &s.Cwd,
+	} {
+		if v := params.ReadString(field, ""); v != "" {
+			*target = v
+			changed = true
+		}
+	}
+
+	for field, target := range map[string]*bool{
+		config.IsSudo:           &s.IsSudo,
+		config.ExecConcurrently: &s.ExecuteConcurrently,
+		config.AbortOnError:     &s.AbortOnError,
+	} {
+		if sc.flagChanged(field) {
+			*target = params.ReadBool(field, false)
+			changed = true
+		}
+	}
+	if sc.flagChanged(config.Timeout) {
+		s.TimeoutSec = params.ReadInt(config.Timeout, config.DefaultCmdTimeoutSeconds)
+		changed = true
+	}
+
+	if hasScheduleTargets(params) {
+		err = sc.readTargets(ctx, params, s)
+		if err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return errors.New("nothing to update, use the flags of the values to change, see --help")
+	}
+
+	if cs != nil {
+		err = sc.confirmFireTimes(params, cs)
+		if err != nil {
+			return err
+		}
+	}
+
+	s, err = sc.Rport.UpdateSchedule(ctx, s.ID, s)
+	if err != nil {
+		return err
+	}
+
+	return sc.ScheduleRenderer.RenderSchedule(s)
+}
+
+func (sc *ScheduleController) Delete(ctx context.Context, ref string) error {
+	s, err := findSchedule(ctx, sc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	err = sc.Rport.DeleteSchedule(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+
+	return sc.ScheduleRenderer.RenderDelete(&models.OperationStatus{Status: "Schedule successfully deleted"})
+}
+
+// RunNow starts the command of the schedule right away as a detached multi-client job without waiting for
+// the next fire time, the results are available with 'job wait'
+func (sc *ScheduleController) RunNow(ctx context.Context, ref string) error {
+	s, err := findSchedule(ctx, sc.Rport, ref)
+	if err != nil {
+		return err
+	}
+	if s.Type != "" && s.Type != models.ScheduleTypeCommand {
+		return fmt.Errorf("schedules of type %q can't be run from the command line", s.Type)
+	}
+
+	js, err := sc.Rport.StartMultiJob(ctx, &models.WsScriptCommand{
+		ClientIDs:           s.ClientIDs,
+		GroupIDs:            s.GroupIDs,
+		IsSudo:              s.IsSudo,
+		ExecuteConcurrently: s.ExecuteConcurrently,
+		AbortOnError:        s.AbortOnError,
+		TimeoutSec:          s.TimeoutSec,
+		Command:             s.Command,
+		Cwd:                 s.Cwd,
+		Interpreter:         s.Interpreter,
+	})
+	if err != nil {
+		return err
+	}
+
+	return sc.JobRenderer.RenderJobStarted(js)
+}
+
+// confirmFireTimes renders the next fire times of the cron expression, unless --no-prompt is given they
+// have to be confirmed before the schedule is submitted
+func (sc *ScheduleController) confirmFireTimes(params *options.ParameterBag, cs *utils.CronSchedule) error {
+	count := params.ReadInt(config.NextFireTimes, config.DefaultNextFireTimes)
+	if count < 0 {
+		return fmt.Errorf("--%s must not be negative", config.NextFireTimes)
+	}
+
+	// the server evaluates the cron expression in UTC
+	now := sc.now().UTC()
+	if cs.Next(now).IsZero() {
+		return errors.New("the cron expression never fires")
+	}
+
+	err := sc.ScheduleRenderer.RenderFireTimes(cs.NextN(now, count))
+	if err != nil {
+		return err
+	}
+
+	if sc.PromptReader == nil || config.ReadNoPrompt(params) {
+		return nil
+	}
+
+	proceed, err := sc.PromptReader.ReadConfirmation(proceedWithScheduleMsg)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return ErrScheduleNotConfirmed
+	}
+
+	return nil
+}
+
+// readTargets sets the client ids and group ids of the schedule, clients given by name or search are resolved
+// to the ids of the currently connected clients
+func (sc *ScheduleController) readTargets(ctx context.Context, params *options.ParameterBag, s *models.Schedule) error {
+	s.ClientIDs = []string{}
+	s.GroupIDs = nil
+	if gids := params.ReadString(config.GroupIDs, ""); gids != "" {
+		s.GroupIDs = strings.Split(gids, ",")
+	}
+
+	if s.GroupIDs != nil && !hasClientTargets(params) {
+		return nil
+	}
+
+	clientIDs, _, err := getClientIDsFromParams(ctx, sc.Rport, params)
+	if err != nil {
+		return err
+	}
+	if clientIDs == "" {
+		return errors.New("no clients match your targeting criteria")
+	}
+	s.ClientIDs = strings.Split(clientIDs, ",")
+
+	return nil
+}
+
+func (sc *ScheduleController) flagChanged(name string) bool {
+	return sc.FlagsChecker != nil && sc.FlagsChecker.ChangedFlag(name)
+}
+
+func (sc *ScheduleController) now() time.Time {
+	if sc.Now != nil {
+		return sc.Now()
+	}
+	return time.Now()
+}
+
+// readScheduleCommand reads the command from --command or from the command library given by --from-library
+func readScheduleCommand(ctx context.Context, rport *api.Rport, params *options.ParameterBag) (command, interpreter string, err error) {
+	command = params.ReadString(config.Command, "")
+	interpreter = params.ReadString(config.Interpreter, "")
+
+	ref := params.ReadString(config.FromLibrary, "")
+	if ref == "" {
+		if command == "" {
+			return "", "", fmt.Errorf("no command provided, use --%s or --%s", config.Command, config.FromLibrary)
+		}
+		return command, interpreter, nil
+	}
+	if command != "" {
+		return "", "", fmt.Errorf("--%s can't be used with --%s", config.Command, config.FromLibrary)
+	}
+
+	stored, err := findStoredCommand(ctx, rport, ref)
+	if err != nil {
+		return "", "", err
+	}
+	// an interpreter given explicitly wins over the stored one
+	if interpreter == "" {
+		interpreter = stored.Interpreter
+	}
+
+	return stored.Cmd, interpreter, nil
+}
+
+func hasClientTargets(params *options.ParameterBag) bool {
+	return params.ReadString(config.ClientIDs, "") != "" ||
+		config.ReadClientNames(params) != "" ||
+		params.ReadString(config.ClientCombinedSearchFlag, "") != ""
+}
+
+func hasScheduleTargets(params *options.ParameterBag) bool {
+	return hasClientTargets(params) || params.ReadString(config.GroupIDs, "") != ""
+}
+
+// findSchedule finds a schedule by its exact name or, if no schedule has this name, by its id
+func findSchedule(ctx context.Context, rport *api.Rport, ref string) (*models.Schedule, error) {
+	if ref == "" {
+		return nil, errors.New("no schedule name or id provided")
+	}
+
+	resp, err := rport.Schedules(ctx, api.NewPaginationWithLimit(api.ClientsLimitMax), api.NewFilters("name", ref))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, 1)
+	var found *models.Schedule
+	for _, s := range resp.Data {
+		if s.Name == ref {
+			ids = append(ids, s.ID)
+			found = s
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		s, err := rport.Schedule(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("unknown schedule %q: %v", ref, err)
+		}
+		return s, nil
+	case 1:
+		return found, nil
+	default:
+		return nil, fmt.Errorf("%d schedules are named %q, use one of the ids instead: %s", len(ids), ref, strings.Join(ids, ", "))
+	}
+}
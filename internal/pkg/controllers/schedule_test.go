@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+type ScheduleRendererMock struct {
+	schedules    []*models.Schedule
+	schedule     *models.Schedule
+	fireTimes    []time.Time
+	deleteStatus output.KvProvider
+}
+
+func (srm *ScheduleRendererMock) RenderSchedules(schedules []*models.Schedule) error {
+	srm.schedules = schedules
+	return nil
+}
+
+func (srm *ScheduleRendererMock) RenderSchedule(s *models.Schedule) error {
+	srm.schedule = s
+	return nil
+}
+
+func (srm *ScheduleRendererMock) RenderFireTimes(times []time.Time) error {
+	srm.fireTimes = times
+	return nil
+}
+
+func (srm *ScheduleRendererMock) RenderDelete(s output.KvProvider) error {
+	srm.deleteStatus = s
+	return nil
+}
+
+// schedulesHandler serves the given schedules filtered by name like the server does
+func schedulesHandler(t *testing.T, schedules []*models.Schedule) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.SchedulesURL, r.URL.Path)
+		matching := make([]*models.Schedule, 0)
+		for _, s := range schedules {
+			if s.Name == r.URL.Query().Get("filter[name]") {
+				matching = append(matching, s)
+			}
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.SchedulesResponse{Data: matching}))
+	}
+}
+
+func newTestScheduleController(srvURL string, renderer *ScheduleRendererMock) *ScheduleController {
+	return &ScheduleController{
+		Rport:            api.New(srvURL, nil),
+		ScheduleRenderer: renderer,
+		JobRenderer:      &JobRendererMock{},
+		PromptReader:     &PromptReaderMock{},
+		Now: func() time.Time {
+			return time.Date(2022, 3, 1, 10, 17, 0, 0, time.UTC)
+		},
+	}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, api.SchedulesURL, r.URL.Path)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body = string(b)
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.ScheduleResponse{Data: &models.Schedule{ID: "s1", Name: "nightly-cleanup"}}))
+	}))
+	defer srv.Close()
+
+	renderer := &ScheduleRendererMock{}
+	sc := newTestScheduleController(srv.URL, renderer)
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:     "c1,c2",
+		config.Command:       "rm -rf /tmp/cache",
+		config.Cron:          "0 3 * * *",
+		config.NextFireTimes: "2",
+		config.IsSudo:        "true",
+		config.NoPrompt:      "true",
+	})
+	err := sc.Create(context.Background(), params, "nightly-cleanup")
+	require.NoError(t, err)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 3, 2, 3, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 3, 3, 0, 0, 0, time.UTC),
+	}, renderer.fireTimes)
+	assert.JSONEq(
+		t,
+		`{"name":"nightly-cleanup","schedule":"0 3 * * *","type":"command","client_ids":["c1","c2"],"command":"rm -rf /tmp/cache","interpreter":"","cwd":"","is_sudo":true,"timeout_sec":30,"execute_concurrently":false,"abort_on_error":false}`,
+		body,
+	)
+	assert.Equal(t, "s1", renderer.schedule.ID)
+}
+
+func TestCreateScheduleNotSubmitted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		name          string
+		cron          string
+		expectedError string
+	}{
+		{
+			name:          "not confirmed",
+			cron:          "@daily",
+			expectedError: ErrScheduleNotConfirmed.Error(),
+		},
+		{
+			name:          "invalid cron",
+			cron:          "0 25 * * *",
+			expectedError: `invalid cron expression "0 25 * * *": value 25 out of range 0-23 in hour field`,
+		},
+		{
+			name:          "never fires",
+			cron:          "0 0 31 2 *",
+			expectedError: "the cron expression never fires",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := newTestScheduleController(srv.URL, &ScheduleRendererMock{})
+			params := config.FromValues(map[string]string{
+				config.GroupIDs: "g1",
+				config.Command:  "uptime",
+				config.Cron:     tc.cron,
+			})
+
+			err := sc.Create(context.Background(), params, "uptime")
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestUpdateScheduleKeepsUnchangedValues(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.Handle(api.SchedulesURL, schedulesHandler(t, []*models.Schedule{
+		{
+			ID:         "s1",
+			Name:       "nightly-cleanup",
+			Schedule:   "0 3 * * *",
+			Type:       models.ScheduleTypeCommand,
+			ClientIDs:  []string{"c1"},
+			Command:    "rm -rf /tmp/cache",
+			IsSudo:     true,
+			TimeoutSec: 60,
+		},
+	}))
+	mux.HandleFunc(api.SchedulesURL+"/s1", func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body = string(b)
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.ScheduleResponse{Data: &models.Schedule{ID: "s1"}}))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	renderer := &ScheduleRendererMock{}
+	sc := newTestScheduleController(srv.URL, renderer)
+	sc.FlagsChecker = UsedFlagsCheckerMock{config.IsSudo: true}
+
+	params := config.FromValues(map[string]string{
+		config.Cwd:     "/tmp",
+		config.IsSudo:  "false",
+		config.Timeout: "30",
+	})
+	err := sc.Update(context.Background(), params, "nightly-cleanup")
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"name":"nightly-cleanup","schedule":"0 3 * * *","type":"command","client_ids":["c1"],"command":"rm -rf /tmp/cache","interpreter":"","cwd":"/tmp","is_sudo":false,"timeout_sec":60,"execute_concurrently":false,"abort_on_error":false}`,
+		body,
+	)
+	assert.Nil(t, renderer.fireTimes)
+
+	sc.FlagsChecker = UsedFlagsCheckerMock{}
+	err = sc.Update(context.Background(), config.FromValues(map[string]string{config.Timeout: "30"}), "nightly-cleanup")
+	assert.EqualError(t, err, "nothing to update, use the flags of the values to change, see --help")
+}
+
+func TestRunScheduleNow(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(api.SchedulesURL, schedulesHandler(t, []*models.Schedule{
+		{
+			ID:         "s1",
+			Name:       "nightly-cleanup",
+			Schedule:   "0 3 * * *",
+			Type:       models.ScheduleTypeCommand,
+			ClientIDs:  []string{"c1"},
+			Command:    "rm -rf /tmp/cache",
+			TimeoutSec: 60,
+		},
+	}))
+	mux.HandleFunc(api.MultiJobsURL, func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(
+			t,
+			`{"client_ids":["c1"],"is_sudo":false,"execute_concurrently":false,"abort_on_error":false,"timeout_sec":60,"command":"rm -rf /tmp/cache","script":"","cwd":"","interpreter":""}`,
+			string(b),
+		)
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.JobStartedResponse{Data: &models.JobStarted{Jid: "mj1"}}))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	sc := newTestScheduleController(srv.URL, &ScheduleRendererMock{})
+	err := sc.RunNow(context.Background(), "nightly-cleanup")
+	require.NoError(t, err)
+	assert.Equal(t, "mj1", sc.JobRenderer.(*JobRendererMock).jobStartedToRender.Jid)
+}
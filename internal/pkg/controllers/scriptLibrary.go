@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	options "github.com/breathbath/go_utils/v2/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+const defaultEditedScriptExt = ".sh"
+
+type StoredScriptRenderer interface {
+	RenderStoredScripts(scripts []*models.StoredScript) error
+	RenderStoredScript(ss *models.StoredScript) error
+	RenderDelete(s output.KvProvider) error
+}
+
+type ScriptLibraryController struct {
+	Rport                *api.Rport
+	StoredScriptRenderer StoredScriptRenderer
+	// FlagsChecker tells if a bool flag like --is_sudo was given to update it
+	FlagsChecker config.UsedFlagsChecker
+	// Editor opens the file with the given path for editing and returns when the editor is closed
+	Editor func(path string) error
+}
+
+func (slc *ScriptLibraryController) List(ctx context.Context, params *options.ParameterBag) error {
+	pagination := api.NewPaginationFromParams(params)
+	resp, err := slc.Rport.StoredScripts(ctx, pagination, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.Truncated(pagination) {
+		logrus.Warnf(
+			"showing %d of %d stored scripts, use --%s and --%s to see more",
+			len(resp.Data),
+			resp.Meta.Count,
+			api.PaginationLimit,
+			api.PaginationOffset,
+		)
+	}
+
+	return slc.StoredScriptRenderer.RenderStoredScripts(resp.Data)
+}
+
+func (slc *ScriptLibraryController) Get(ctx context.Context, ref string) error {
+	ss, err := findStoredScript(ctx, slc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	return slc.StoredScriptRenderer.RenderStoredScript(ss)
+}
+
+// Create stores the script from the --script file or from --exec, without --interpreter it's guessed from the file extension
+func (slc *ScriptLibraryController) Create(ctx context.Context, params *options.ParameterBag, name string) error {
+	if name == "" {
+		return errors.New("no name provided")
+	}
+	script, err := readScriptParam(params)
+	if err != nil {
+		return err
+	}
+	if script == "" {
+		return fmt.Errorf("no script provided, use --%s or --%s", config.Script, config.EmbeddedScript)
+	}
+
+	interpreter := params.ReadString(config.Interpreter, "")
+	if interpreter == "" {
+		interpreter = fileExtInterpreterMap[filepath.Ext(params.ReadString(config.Script, ""))]
+	}
+
+	ss, err := slc.Rport.CreateStoredScript(ctx, &models.StoredScript{
+		Name:        name,
+		Script:      script,
+		Interpreter: interpreter,
+		Cwd:         params.ReadString(config.Cwd, ""),
+		IsSudo:      params.ReadBool(config.IsSudo, false),
+	})
+	if err != nil {
+		return err
+	}
+
+	return slc.StoredScriptRenderer.RenderStoredScript(ss)
+}
+
+// Update changes only the values given by the flags and keeps the other ones
+func (slc *ScriptLibraryController) Update(ctx context.Context, params *options.ParameterBag, ref string) error {
+	ss, err := findStoredScript(ctx, slc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	script, err := readScriptParam(params)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if name := params.ReadString(config.NewName, ""); name != "" {
+		ss.Name = name
+		changed = true
+	}
+	if script != "" {
+		ss.Script = script
+		changed = true
+	}
+	if interpreter := params.ReadString(config.Interpreter, ""); interpreter != "" {
+		ss.Interpreter = interpreter
+		changed = true
+	}
+	if cwd := params.ReadString(config.Cwd, ""); cwd != "" {
+		ss.Cwd = cwd
+		changed = true
+	}
+	// --is_sudo=false must be possible, so the flag counts if it's given
+	if slc.FlagsChecker != nil && slc.FlagsChecker.ChangedFlag(config.IsSudo) {
+		ss.IsSudo = params.ReadBool(config.IsSudo, false)
+		changed = true
+	}
+	if !changed {
+		return fmt.Errorf(
+			"nothing to update, use --%s, --%s, --%s, --%s, --%s or --%s",
+			config.NewName,
+			config.Script,
+			config.EmbeddedScript,
+			config.Interpreter,
+			config.Cwd,
+			config.IsSudo,
+		)
+	}
+
+	return slc.update(ctx, ss)
+}
+
+// Edit opens the stored script in the editor and uploads it once the editor is closed and the script was changed
+func (slc *ScriptLibraryController) Edit(ctx context.Context, ref string) error {
+	ss, err := findStoredScript(ctx, slc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp("", "rportcli-script-*"+editedScriptExt(ss.Interpreter))
+	if err != nil {
+		return err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	_, err = f.WriteString(ss.Script)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	err = slc.Editor(path)
+	if err != nil {
+		return fmt.Errorf("editor failed, the stored script is unchanged: %w", err)
+	}
+
+	edited, err := readScriptFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(edited, []byte(ss.Script)) {
+		logrus.Infof("the stored script %q is unchanged", ss.Name)
+		return nil
+	}
+	ss.Script = string(edited)
+
+	return slc.update(ctx, ss)
+}
+
+func (slc *ScriptLibraryController) Delete(ctx context.Context, ref string) error {
+	ss, err := findStoredScript(ctx, slc.Rport, ref)
+	if err != nil {
+		return err
+	}
+
+	err = slc.Rport.DeleteStoredScript(ctx, ss.ID)
+	if err != nil {
+		return err
+	}
+
+	return slc.StoredScriptRenderer.RenderDelete(&models.OperationStatus{Status: "Stored script successfully deleted"})
+}
+
+func (slc *ScriptLibraryController) update(ctx context.Context, ss *models.StoredScript) error {
+	ss, err := slc.Rport.UpdateStoredScript(ctx, ss.ID, ss)
+	if err != nil {
+		return err
+	}
+
+	return slc.StoredScriptRenderer.RenderStoredScript(ss)
+}
+
+// readScriptParam reads the script from the --script file or from --exec, it's empty if none of them is given
+func readScriptParam(params *options.ParameterBag) (string, error) {
+	path := params.ReadString(config.Script, "")
+	embedded := params.ReadString(config.EmbeddedScript, "")
+	if path != "" && embedded != "" {
+		return "", fmt.Errorf("--%s and --%s can't be used together", config.Script, config.EmbeddedScript)
+	}
+	if path == "" {
+		return embedded, nil
+	}
+
+	content, err := readScriptFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// editedScriptExt gives the temp file of an edited script an extension matching the interpreter,
+// so that editors can highlight the syntax
+func editedScriptExt(interpreter string) string {
+	for ext, i := range fileExtInterpreterMap {
+		if i == interpreter {
+			return ext
+		}
+	}
+
+	return defaultEditedScriptExt
+}
+
+// findStoredScript finds a stored script by its exact name or, if no script has this name, by its id
+func findStoredScript(ctx context.Context, rport *api.Rport, ref string) (*models.StoredScript, error) {
+	if ref == "" {
+		return nil, errors.New("no stored script name or id provided")
+	}
+
+	resp, err := rport.StoredScripts(ctx, api.NewPaginationWithLimit(api.ClientsLimitMax), api.NewFilters("name", ref))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, 1)
+	var found *models.StoredScript
+	for _, ss := range resp.Data {
+		if ss.Name == ref {
+			ids = append(ids, ss.ID)
+			found = ss
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		ss, err := rport.StoredScript(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("unknown stored script %q: %v", ref, err)
+		}
+		return ss, nil
+	case 1:
+		return found, nil
+	default:
+		return nil, ambiguousLibraryNameError("scripts", ref, ids)
+	}
+}
@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/output"
+)
+
+type StoredScriptRendererMock struct {
+	scripts      []*models.StoredScript
+	script       *models.StoredScript
+	deleteStatus output.KvProvider
+}
+
+func (ssrm *StoredScriptRendererMock) RenderStoredScripts(scripts []*models.StoredScript) error {
+	ssrm.scripts = scripts
+	return nil
+}
+
+func (ssrm *StoredScriptRendererMock) RenderStoredScript(ss *models.StoredScript) error {
+	ssrm.script = ss
+	return nil
+}
+
+func (ssrm *StoredScriptRendererMock) RenderDelete(s output.KvProvider) error {
+	ssrm.deleteStatus = s
+	return nil
+}
+
+type UsedFlagsCheckerMock map[string]bool
+
+func (ufcm UsedFlagsCheckerMock) ChangedFlag(name string) bool {
+	return ufcm[name]
+}
+
+// storedScriptsHandler serves the given stored scripts filtered by name like the server does
+func storedScriptsHandler(t *testing.T, scripts []*models.StoredScript) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.StoredScriptsURL, r.URL.Path)
+		matching := make([]*models.StoredScript, 0)
+		for _, ss := range scripts {
+			if ss.Name == r.URL.Query().Get("filter[name]") {
+				matching = append(matching, ss)
+			}
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.StoredScriptsResponse{Data: matching}))
+	}
+}
+
+// storedScriptUpdateHandler responds with the script sent by the client and records its body
+func storedScriptUpdateHandler(t *testing.T, body *string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*body = string(b)
+		ss := &models.StoredScript{}
+		assert.NoError(t, json.Unmarshal(b, ss))
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.StoredScriptResponse{Data: ss}))
+	}
+}
+
+func TestUpdateStoredScriptKeepsUnchangedValues(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.Handle(api.StoredScriptsURL, storedScriptsHandler(t, []*models.StoredScript{
+		{ID: "ss1", Name: "cleanup", Script: "rm -rf /tmp/cache", Interpreter: "bash", Cwd: "/tmp", IsSudo: true},
+	}))
+	mux.Handle(api.StoredScriptsURL+"/ss1", storedScriptUpdateHandler(t, &body))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	renderer := &StoredScriptRendererMock{}
+	slc := &ScriptLibraryController{
+		Rport:                api.New(srv.URL, nil),
+		StoredScriptRenderer: renderer,
+		FlagsChecker:         UsedFlagsCheckerMock{config.IsSudo: true},
+	}
+
+	params := config.FromValues(map[string]string{config.Cwd: "/var/tmp", config.IsSudo: "false"})
+	err := slc.Update(context.Background(), params, "cleanup")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"cleanup","script":"rm -rf /tmp/cache","interpreter":"bash","cwd":"/var/tmp","is_sudo":false}`, body)
+	assert.Equal(t, "/var/tmp", renderer.script.Cwd)
+
+	slc.FlagsChecker = UsedFlagsCheckerMock{}
+	err = slc.Update(context.Background(), config.FromValues(map[string]string{}), "cleanup")
+	assert.EqualError(t, err, "nothing to update, use --new-name, --script, --exec, --interpreter, --cwd or --is_sudo")
+}
+
+func TestCreateStoredScriptGuessesInterpreter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"services","script":"Get-Service","interpreter":"powershell","cwd":"","is_sudo":false}`, string(b))
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.StoredScriptResponse{Data: &models.StoredScript{ID: "ss1"}}))
+	}))
+	defer srv.Close()
+
+	scriptFile, err := os.CreateTemp("", "services-*.ps1")
+	require.NoError(t, err)
+	defer os.Remove(scriptFile.Name())
+	_, err = scriptFile.WriteString("Get-Service")
+	require.NoError(t, err)
+	require.NoError(t, scriptFile.Close())
+
+	renderer := &StoredScriptRendererMock{}
+	slc := &ScriptLibraryController{
+		Rport:                api.New(srv.URL, nil),
+		StoredScriptRenderer: renderer,
+	}
+
+	err = slc.Create(context.Background(), config.FromValues(map[string]string{config.Script: scriptFile.Name()}), "services")
+	require.NoError(t, err)
+	assert.Equal(t, "ss1", renderer.script.ID)
+
+	err = slc.Create(context.Background(), config.FromValues(map[string]string{}), "services")
+	assert.EqualError(t, err, "no script provided, use --script or --exec")
+}
+
+func TestEditStoredScript(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.Handle(api.StoredScriptsURL, storedScriptsHandler(t, []*models.StoredScript{
+		{ID: "ss1", Name: "services", Script: "Get-Service", Interpreter: "powershell"},
+	}))
+	mux.Handle(api.StoredScriptsURL+"/ss1", storedScriptUpdateHandler(t, &body))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var editedPath string
+	renderer := &StoredScriptRendererMock{}
+	slc := &ScriptLibraryController{
+		Rport:                api.New(srv.URL, nil),
+		StoredScriptRenderer: renderer,
+		Editor: func(path string) error {
+			editedPath = path
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, "Get-Service", string(content))
+			return os.WriteFile(path, []byte("Get-Service | Where-Object {$_.Status -eq 'Running'}"), 0600)
+		},
+	}
+
+	err := slc.Edit(context.Background(), "services")
+	require.NoError(t, err)
+	assert.Regexp(t, `\.ps1$`, editedPath)
+	assert.NoFileExists(t, editedPath)
+	assert.JSONEq(
+		t,
+		`{"name":"services","script":"Get-Service | Where-Object {$_.Status -eq 'Running'}","interpreter":"powershell","cwd":"","is_sudo":false}`,
+		body,
+	)
+
+	body = ""
+	renderer.script = nil
+	slc.Editor = func(path string) error {
+		return nil
+	}
+	err = slc.Edit(context.Background(), "services")
+	require.NoError(t, err)
+	assert.Empty(t, body)
+	assert.Nil(t, renderer.script)
+}
+
+func TestScriptExecutionFromLibrary(t *testing.T) {
+	srv := httptest.NewServer(storedScriptsHandler(t, []*models.StoredScript{
+		{ID: "ss1", Name: "cleanup", Script: "rm -rf /tmp/cache", Interpreter: "bash", Cwd: "/tmp", IsSudo: true},
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		name           string
+		params         map[string]string
+		explicitParams UsedFlagsCheckerMock
+		expectedJSON   string
+	}{
+		{
+			name:         "stored values",
+			params:       map[string]string{config.ClientIDs: "1235", config.FromLibrary: "cleanup"},
+			expectedJSON: `{"client_ids":["1235"],"is_sudo":true,"execute_concurrently":false,"abort_on_error":false,"timeout_sec":30,"command":"","script":"cm0gLXJmIC90bXAvY2FjaGU=","cwd":"/tmp","interpreter":"bash"}`,
+		},
+		{
+			name: "explicit values win",
+			params: map[string]string{
+				config.ClientIDs:   "1235",
+				config.FromLibrary: "cleanup",
+				config.Interpreter: "sh",
+				config.IsSudo:      "false",
+			},
+			explicitParams: UsedFlagsCheckerMock{config.Interpreter: true, config.IsSudo: true},
+			expectedJSON:   `{"client_ids":["1235"],"is_sudo":false,"execute_concurrently":false,"abort_on_error":false,"timeout_sec":30,"command":"","script":"cm0gLXJmIC90bXAvY2FjaGU=","cwd":"/tmp","interpreter":"sh"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rw := makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "1235"))
+			sc := &ScriptsController{
+				ExecutionHelper: &ExecutionHelper{
+					ReadWriter:  rw,
+					JobRenderer: &JobRendererMock{},
+					Rport:       api.New(srv.URL, nil),
+				},
+				ExplicitParams: tc.explicitParams,
+			}
+
+			err := sc.Start(context.Background(), config.FromValues(tc.params), nil, nil)
+			require.NoError(t, err)
+
+			require.Len(t, rw.writtenItems, 1)
+			assert.JSONEq(t, tc.expectedJSON, rw.writtenItems[0])
+		})
+	}
+}
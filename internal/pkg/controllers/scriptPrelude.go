@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+)
+
+const (
+	shellPosix      = "posix"
+	shellPowerShell = "powershell"
+	shellCmd        = "cmd"
+
+	// cmdScriptLabel is the label the script is called at by the cmd prelude to receive the arguments as %1, %2, ...
+	cmdScriptLabel = "rportcli_script"
+)
+
+var interpreterShells = map[string]string{
+	"sh":         shellPosix,
+	"bash":       shellPosix,
+	"zsh":        shellPosix,
+	"dash":       shellPosix,
+	"ksh":        shellPosix,
+	"powershell": shellPowerShell,
+	"pwsh":       shellPowerShell,
+	"cmd":        shellCmd,
+}
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// scriptEnvVar is a variable given by --env KEY=VALUE
+type scriptEnvVar struct {
+	name  string
+	value string
+}
+
+// scriptPrelude is put around the script to export the --env variables and to set the --arg positional arguments
+// in the syntax of the interpreter, all values are quoted to be taken literally
+type scriptPrelude struct {
+	prefix string
+	suffix string
+}
+
+func newScriptPrelude(interpreter string, args, env []string) (*scriptPrelude, error) {
+	if len(args) == 0 && len(env) == 0 {
+		return &scriptPrelude{}, nil
+	}
+
+	shell, err := resolveShell(interpreter)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := parseScriptEnv(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch shell {
+	case shellPowerShell:
+		return powerShellPrelude(args, vars), nil
+	case shellCmd:
+		return cmdPrelude(args, vars)
+	default:
+		return posixPrelude(args, vars), nil
+	}
+}
+
+// wrap returns the script with the prelude, for --template the prelude is kept out of rendering by turning it
+// into template string constants, otherwise the rendered values would no longer be quoted
+func (sp *scriptPrelude) wrap(script []byte, isTemplate bool) []byte {
+	prefix, suffix := sp.prefix, sp.suffix
+	if isTemplate {
+		prefix, suffix = templateConstant(prefix), templateConstant(suffix)
+	}
+
+	return []byte(prefix + string(script) + suffix)
+}
+
+func templateConstant(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "{{" + strconv.Quote(s) + "}}"
+}
+
+// resolveShell tells the syntax of the prelude by the interpreter name, which can also be a path like /bin/bash
+// or C:\Windows\System32\cmd.exe
+func resolveShell(interpreter string) (string, error) {
+	if interpreter == "" {
+		return "", fmt.Errorf(
+			"--%s is required with --%s and --%s to pass them in the syntax of the interpreter",
+			config.Interpreter,
+			config.ScriptArg,
+			config.ScriptEnv,
+		)
+	}
+
+	name := interpreter
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+
+	shell, ok := interpreterShells[name]
+	if !ok {
+		return "", fmt.Errorf(
+			"--%s and --%s are not supported for the interpreter %s, use sh, bash, zsh, powershell, pwsh or cmd",
+			config.ScriptArg,
+			config.ScriptEnv,
+			interpreter,
+		)
+	}
+
+	return shell, nil
+}
+
+func parseScriptEnv(env []string) ([]scriptEnvVar, error) {
+	vars := make([]scriptEnvVar, 0, len(env))
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || !envNamePattern.MatchString(parts[0]) {
+			return nil, fmt.Errorf(
+				"invalid --%s %q, expected KEY=VALUE with a KEY of letters, digits and underscores",
+				config.ScriptEnv,
+				kv,
+			)
+		}
+		vars = append(vars, scriptEnvVar{name: parts[0], value: parts[1]})
+	}
+
+	return vars, nil
+}
+
+func posixPrelude(args []string, vars []scriptEnvVar) *scriptPrelude {
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "export %s=%s\n", v.name, quotePosix(v.value))
+	}
+	if len(args) > 0 {
+		b.WriteString("set --")
+		for _, arg := range args {
+			b.WriteString(" " + quotePosix(arg))
+		}
+		b.WriteString("\n")
+	}
+
+	return &scriptPrelude{prefix: b.String()}
+}
+
+// quotePosix puts the value in single quotes, in which nothing is expanded,
+// a single quote is closed, escaped and reopened
+func quotePosix(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// powerShellPrelude sets the variables and runs the script as script block to pass the arguments as $args
+// and to the param block of the script
+func powerShellPrelude(args []string, vars []scriptEnvVar) *scriptPrelude {
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "$env:%s = %s\n", v.name, quotePowerShell(v.value))
+	}
+	if len(args) == 0 {
+		return &scriptPrelude{prefix: b.String()}
+	}
+	b.WriteString("& {\n")
+
+	var suffix strings.Builder
+	suffix.WriteString("\n}")
+	for _, arg := range args {
+		suffix.WriteString(" " + quotePowerShell(arg))
+	}
+	suffix.WriteString("\n")
+
+	return &scriptPrelude{prefix: b.String(), suffix: suffix.String()}
+}
+
+// quotePowerShell puts the value in single quotes, in which nothing is expanded, PowerShell also takes the typographic
+// single quotes as quotes, each of them is escaped by doubling it
+func quotePowerShell(value string) string {
+	var b strings.Builder
+	b.WriteString("'")
+	for _, r := range value {
+		if strings.ContainsRune("'\u2018\u2019\u201A\u201B", r) {
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString("'")
+
+	return b.String()
+}
+
+// cmdPrelude sets the variables and calls the script as subroutine to pass the arguments as %1, %2, ...,
+// cmd has no quoting which protects all characters, values which can't be passed literally are refused
+func cmdPrelude(args []string, vars []scriptEnvVar) (*scriptPrelude, error) {
+	var b strings.Builder
+	for _, v := range vars {
+		if strings.ContainsAny(v.value, "\"\r\n") {
+			return nil, fmt.Errorf("--%s %s: double quotes and line breaks can't be passed to cmd", config.ScriptEnv, v.name)
+		}
+		// inside quotes only percent signs are expanded by set
+		fmt.Fprintf(&b, "@set \"%s=%s\"\r\n", v.name, strings.ReplaceAll(v.value, "%", "%%"))
+	}
+	if len(args) == 0 {
+		return &scriptPrelude{prefix: b.String()}, nil
+	}
+
+	b.WriteString("@call :" + cmdScriptLabel)
+	for _, arg := range args {
+		// call expands percent signs twice and doubles carets even inside quotes
+		if strings.ContainsAny(arg, "\"%^\r\n") {
+			return nil, fmt.Errorf(
+				"--%s %s: double quotes, %%, ^ and line breaks can't be passed to cmd",
+				config.ScriptArg,
+				strconv.Quote(arg),
+			)
+		}
+		b.WriteString(" \"" + arg + "\"")
+	}
+	b.WriteString("\r\n@exit /b %ERRORLEVEL%\r\n:" + cmdScriptLabel + "\r\n")
+
+	return &scriptPrelude{prefix: b.String()}, nil
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestScriptPreludeWrap(t *testing.T) {
+	const script = "echo done\n"
+
+	testCases := []struct {
+		Name           string
+		Interpreter    string
+		Args           []string
+		Env            []string
+		ExpectedScript string
+		ExpectedError  string
+	}{
+		{
+			Name:           "no args and env",
+			ExpectedScript: script,
+		},
+		{
+			Name:        "sh",
+			Interpreter: "/bin/sh",
+			Args:        []string{"it's", "$HOME `id`", ""},
+			Env:         []string{"GREETING=a=b c", "EMPTY="},
+			ExpectedScript: "export GREETING='a=b c'\n" +
+				"export EMPTY=''\n" +
+				"set -- 'it'\\''s' '$HOME `id`' ''\n" +
+				script,
+		},
+		{
+			Name:           "bash env only",
+			Interpreter:    "bash",
+			Env:            []string{"MULTI=line1\nline2"},
+			ExpectedScript: "export MULTI='line1\nline2'\n" + script,
+		},
+		{
+			Name:        "powershell",
+			Interpreter: "C:\\Program Files\\PowerShell\\7\\pwsh.exe",
+			Args:        []string{"it's", "$env:PATH", "\u2019quoted\u2018"},
+			Env:         []string{"TARGET=C:\\Program Files\\App"},
+			ExpectedScript: "$env:TARGET = 'C:\\Program Files\\App'\n" +
+				"& {\n" +
+				script +
+				"\n} 'it''s' '$env:PATH' '\u2019\u2019quoted\u2018\u2018'\n",
+		},
+		{
+			Name:           "powershell env only",
+			Interpreter:    "powershell",
+			Env:            []string{"A=1"},
+			ExpectedScript: "$env:A = '1'\n" + script,
+		},
+		{
+			Name:        "cmd",
+			Interpreter: "cmd.exe",
+			Args:        []string{"a b", "x&y|z<>"},
+			Env:         []string{"RATE=100%", "CHAIN=a&b^c"},
+			ExpectedScript: "@set \"RATE=100%%\"\r\n" +
+				"@set \"CHAIN=a&b^c\"\r\n" +
+				"@call :rportcli_script \"a b\" \"x&y|z<>\"\r\n" +
+				"@exit /b %ERRORLEVEL%\r\n" +
+				":rportcli_script\r\n" +
+				script,
+		},
+		{
+			Name:          "cmd arg with percent",
+			Interpreter:   "cmd",
+			Args:          []string{"100%"},
+			ExpectedError: `--arg "100%": double quotes, %, ^ and line breaks can't be passed to cmd`,
+		},
+		{
+			Name:          "cmd env with quote",
+			Interpreter:   "cmd",
+			Env:           []string{`A=say "hi"`},
+			ExpectedError: "--env A: double quotes and line breaks can't be passed to cmd",
+		},
+		{
+			Name:          "no interpreter",
+			Args:          []string{"a"},
+			ExpectedError: "--interpreter is required with --arg and --env to pass them in the syntax of the interpreter",
+		},
+		{
+			Name:          "unsupported interpreter",
+			Interpreter:   "python3",
+			Env:           []string{"A=1"},
+			ExpectedError: "--arg and --env are not supported for the interpreter python3, use sh, bash, zsh, powershell, pwsh or cmd",
+		},
+		{
+			Name:          "invalid env name",
+			Interpreter:   "sh",
+			Env:           []string{"MY-VAR=1"},
+			ExpectedError: `invalid --env "MY-VAR=1", expected KEY=VALUE with a KEY of letters, digits and underscores`,
+		},
+		{
+			Name:          "env without value",
+			Interpreter:   "sh",
+			Env:           []string{"A"},
+			ExpectedError: `invalid --env "A", expected KEY=VALUE with a KEY of letters, digits and underscores`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			prelude, err := newScriptPrelude(tc.Interpreter, tc.Args, tc.Env)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedScript, string(prelude.wrap([]byte(script), false)))
+		})
+	}
+}
+
+func TestScriptPreludeWrapTemplate(t *testing.T) {
+	prelude, err := newScriptPrelude("pwsh", []string{"{{.Name}}", `"\`}, []string{"A={{.ID}}"})
+	require.NoError(t, err)
+
+	wsCmd := &models.WsScriptCommand{ClientIDs: []string{"cl1"}, Script: "script"}
+	script := prelude.wrap([]byte("echo {{.Name}}"), true)
+	variants, err := renderTemplate(wsCmd, string(script), map[string]*models.Client{"cl1": {ID: "cl1", Name: "web1"}})
+	require.NoError(t, err)
+
+	require.Len(t, variants, 1)
+	assert.Equal(t, "$env:A = '{{.ID}}'\n& {\necho web1\n} '{{.Name}}' '\"\\'\n", variants[0].Script)
+}
@@ -9,7 +9,9 @@ import (
 	"path/filepath"
 
 	options "github.com/breathbath/go_utils/v2/pkg/config"
+	io2 "github.com/breathbath/go_utils/v2/pkg/io"
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
 )
 
 var fileExtInterpreterMap = map[string]string{
@@ -19,6 +21,8 @@ var fileExtInterpreterMap = map[string]string{
 
 type ScriptsController struct {
 	*ExecutionHelper
+	// ExplicitParams tells which parameters override the values of a script from the library
+	ExplicitParams config.UsedFlagsChecker
 }
 
 func (cc *ScriptsController) Start(ctx context.Context,
@@ -27,8 +31,22 @@ func (cc *ScriptsController) Start(ctx context.Context,
 	hostInfo *config.HostInfo) (err error) {
 	var scriptContent []byte
 	var interpreter string
-	interpreter = params.ReadString(config.Interpreter, "")
 	scriptsFilePath := params.ReadString(config.Script, "")
+	embeddedScriptContent := params.ReadString(config.EmbeddedScript, "")
+
+	if ref := params.ReadString(config.FromLibrary, ""); ref != "" {
+		if scriptsFilePath != "" || embeddedScriptContent != "" {
+			return fmt.Errorf("--%s and --%s can't be used with --%s", config.Script, config.EmbeddedScript, config.FromLibrary)
+		}
+		ss, err := findStoredScript(ctx, cc.Rport, ref)
+		if err != nil {
+			return err
+		}
+		params = cc.withStoredScript(params, ss)
+		scriptContent = []byte(ss.Script)
+	}
+
+	interpreter = params.ReadString(config.Interpreter, "")
 	if interpreter == "" && scriptsFilePath != "" {
 		// If interpreter is not set, try to guess from the file extension
 		interpreter = cc.resolveInterpreterByFileName(scriptsFilePath, params.ReadString(config.Interpreter, ""))
@@ -39,21 +57,45 @@ func (cc *ScriptsController) Start(ctx context.Context,
 		if err != nil {
 			return err
 		}
-	} else {
-		embeddedScriptContent := params.ReadString(config.EmbeddedScript, "")
-		if embeddedScriptContent != "" {
-			scriptContent = []byte(embeddedScriptContent)
-		} else {
-			scriptContent = []byte("")
-		}
+	} else if embeddedScriptContent != "" {
+		scriptContent = []byte(embeddedScriptContent)
+	} else if scriptContent == nil {
+		scriptContent = []byte("")
 	}
 
+	prelude, err := newScriptPrelude(interpreter, config.ReadScriptArgs(params), config.ReadScriptEnv(params))
+	if err != nil {
+		return err
+	}
+	scriptContent = prelude.wrap(scriptContent, params.ReadBool(config.Template, false))
+
 	scriptContentBase64 := base64.StdEncoding.EncodeToString(scriptContent)
 
-	return cc.execute(ctx, params, scriptContentBase64, interpreter, promptReader, hostInfo)
+	return cc.execute(ctx, params, "", scriptContentBase64, interpreter, promptReader, hostInfo)
+}
+
+// withStoredScript layers the interpreter, cwd and sudo flag of a stored script below the parameters given
+// explicitly on the command line or in a yaml file, the same way yaml parameters are layered below command line flags
+func (cc *ScriptsController) withStoredScript(params *options.ParameterBag, ss *models.StoredScript) *options.ParameterBag {
+	stored := map[string]interface{}{
+		config.Interpreter: ss.Interpreter,
+		config.Cwd:         ss.Cwd,
+		config.IsSudo:      ss.IsSudo,
+	}
+	for name := range stored {
+		if cc.ExplicitParams != nil && cc.ExplicitParams.ChangedFlag(name) {
+			delete(stored, name)
+		}
+	}
+
+	return options.New(options.NewValuesProviderComposite(options.NewMapValuesProvider(stored), params.BaseValuesProvider))
 }
 
 func (cc *ScriptsController) ReadScriptContent(scriptsFilePath string) (scriptContent []byte, err error) {
+	return readScriptFile(scriptsFilePath)
+}
+
+func readScriptFile(scriptsFilePath string) (scriptContent []byte, err error) {
 	info, err := os.Stat(scriptsFilePath)
 	if os.IsNotExist(err) {
 		return nil, fmt.Errorf("script file doesn't exist: %s", scriptsFilePath)
@@ -66,6 +108,7 @@ func (cc *ScriptsController) ReadScriptContent(scriptsFilePath string) (scriptCo
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", scriptsFilePath, err)
 	}
+	defer io2.CloseResourceSecure("script file", scriptFile)
 
 	scriptContent, err = io.ReadAll(scriptFile)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	options "github.com/breathbath/go_utils/v2/pkg/config"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 
@@ -167,6 +168,9 @@ func TestScriptExecutionByClientIDsSuccess(t *testing.T) {
 			paramsContainer := config.FromValues(params)
 
 			jobToGive := buildJob()
+			if tc.commandToExpect != nil {
+				jobToGive.ClientID = tc.commandToExpect.ClientIDs[0]
+			}
 			sc, rw, jr, err := buildScriptController(jobToGive)
 			require.NoError(t, err)
 
@@ -231,6 +235,28 @@ func buildJob() *models.Job {
 	}
 }
 
+func TestScriptExecutionWithArgsAndEnv(t *testing.T) {
+	sc, rw, _, err := buildScriptController(buildJob())
+	require.NoError(t, err)
+
+	params := options.New(options.NewMapValuesProvider(map[string]interface{}{
+		config.ClientIDs:      "2222",
+		config.EmbeddedScript: `echo "$1 $TARGET"`,
+		config.Interpreter:    "bash",
+		config.ScriptArg:      []string{"a, b"},
+		config.ScriptEnv:      []string{"TARGET=/opt/app"},
+	}))
+	err = sc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, rw.writtenItems, 1)
+	wsCmd := &models.WsScriptCommand{}
+	require.NoError(t, json.Unmarshal([]byte(rw.writtenItems[0]), wsCmd))
+	script, err := base64.StdEncoding.DecodeString(wsCmd.Script)
+	require.NoError(t, err)
+	assert.Equal(t, "export TARGET='/opt/app'\nset -- 'a, b'\necho \"$1 $TARGET\"", string(script))
+}
+
 func buildScriptController(j *models.Job) (*ScriptsController, *ReadWriterMock, *JobRendererMock, error) {
 	jobRespBytes, err := json.Marshal(j)
 	if err != nil {
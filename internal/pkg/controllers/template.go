@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+// templateClientFields are the fields of the client record available to --template
+var templateClientFields = api.Fields{
+	"id", "name", "hostname", "address", "ipv4", "ipv6", "tags", "timezone", "version",
+	"os", "os_arch", "os_family", "os_kernel", "os_full_name", "os_version",
+	"os_virtualization_system", "os_virtualization_role",
+	"cpu_family", "cpu_model", "cpu_model_name", "cpu_vendor", "num_cpus", "mem_total",
+	"connection_state", "disconnected_at", "allowed_user_groups",
+}
+
+// renderVariants renders the command or script once per targeted client if --template is given and groups the clients
+// with identical results in the order of their first occurrence, without --template all clients share one variant,
+// nil is returned if neither --template nor --dry-run is given
+func (eh *ExecutionHelper) renderVariants(
+	ctx context.Context,
+	wsCmd *models.WsScriptCommand,
+	opts *executeOptions,
+) ([]*models.CommandVariant, error) {
+	if !opts.template && !opts.dryRun {
+		return nil, nil
+	}
+
+	payload := wsCmd.Command
+	if wsCmd.Script != "" {
+		script, err := base64.StdEncoding.DecodeString(wsCmd.Script)
+		if err != nil {
+			return nil, err
+		}
+		payload = string(script)
+	}
+
+	fields := api.Fields{"id", "name"}
+	if opts.template {
+		fields = templateClientFields
+	}
+	clients, err := eh.Rport.AllClientsWithFields(ctx, api.NewFilters("id", strings.Join(wsCmd.ClientIDs, ",")), fields, nil)
+	if err != nil {
+		return nil, err
+	}
+	clientsByID := make(map[string]*models.Client, len(clients))
+	for _, c := range clients {
+		clientsByID[c.ID] = c
+	}
+
+	if !opts.template {
+		variant := newCommandVariant(wsCmd, payload)
+		for _, id := range wsCmd.ClientIDs {
+			addVariantClient(variant, id, clientsByID[id])
+		}
+		return []*models.CommandVariant{variant}, nil
+	}
+
+	return renderTemplate(wsCmd, payload, clientsByID)
+}
+
+func renderTemplate(
+	wsCmd *models.WsScriptCommand,
+	payload string,
+	clientsByID map[string]*models.Client,
+) ([]*models.CommandVariant, error) {
+	tmpl, err := template.New(config.Template).Option("missingkey=error").Parse(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the %s as template: %v", payloadKind(wsCmd), err)
+	}
+
+	variants := make([]*models.CommandVariant, 0)
+	variantsByPayload := make(map[string]*models.CommandVariant)
+	for _, id := range wsCmd.ClientIDs {
+		client, ok := clientsByID[id]
+		if !ok {
+			return nil, fmt.Errorf("client %s not found, the %s can't be rendered for it", id, payloadKind(wsCmd))
+		}
+
+		var rendered bytes.Buffer
+		err = tmpl.Execute(&rendered, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render the %s for client %s (%s): %v", payloadKind(wsCmd), client.Name, id, err)
+		}
+
+		variant, ok := variantsByPayload[rendered.String()]
+		if !ok {
+			variant = newCommandVariant(wsCmd, rendered.String())
+			variantsByPayload[rendered.String()] = variant
+			variants = append(variants, variant)
+		}
+		addVariantClient(variant, id, client)
+	}
+
+	return variants, nil
+}
+
+func newCommandVariant(wsCmd *models.WsScriptCommand, payload string) *models.CommandVariant {
+	if wsCmd.Script != "" {
+		return &models.CommandVariant{Script: payload}
+	}
+	return &models.CommandVariant{Command: payload}
+}
+
+// addVariantClient adds the client to the variant, a client unknown to the server is listed by its id
+func addVariantClient(variant *models.CommandVariant, id string, client *models.Client) {
+	name := id
+	if client != nil && client.Name != "" {
+		name = client.Name
+	}
+	variant.ClientIDs = append(variant.ClientIDs, id)
+	variant.Clients = append(variant.Clients, name)
+}
+
+// addVariantClientNames adds the names of the clients fetched to render the variants, a client unknown to the server
+// is left out
+func addVariantClientNames(clientNames map[string]string, variants []*models.CommandVariant) {
+	for _, v := range variants {
+		for i, id := range v.ClientIDs {
+			if v.Clients[i] != id {
+				clientNames[id] = v.Clients[i]
+			}
+		}
+	}
+}
+
+func payloadKind(wsCmd *models.WsScriptCommand) string {
+	if wsCmd.Script != "" {
+		return "script"
+	}
+	return "command"
+}
+
+// batchCommands returns the commands sent to the clients of a batch, one per variant having clients in the batch
+func batchCommands(wsCmd *models.WsScriptCommand, batch []string, variants []*models.CommandVariant) []*models.WsScriptCommand {
+	if variants == nil {
+		batchCmd := *wsCmd
+		batchCmd.ClientIDs = batch
+		return []*models.WsScriptCommand{&batchCmd}
+	}
+
+	inBatch := make(map[string]bool, len(batch))
+	for _, id := range batch {
+		inBatch[id] = true
+	}
+
+	cmds := make([]*models.WsScriptCommand, 0, len(variants))
+	for _, v := range variants {
+		clientIDs := make([]string, 0, len(v.ClientIDs))
+		for _, id := range v.ClientIDs {
+			if inBatch[id] {
+				clientIDs = append(clientIDs, id)
+			}
+		}
+		if len(clientIDs) == 0 {
+			continue
+		}
+
+		variantCmd := *wsCmd
+		variantCmd.ClientIDs = clientIDs
+		if wsCmd.Script != "" {
+			variantCmd.Script = base64.StdEncoding.EncodeToString([]byte(v.Script))
+		} else {
+			variantCmd.Command = v.Command
+		}
+		cmds = append(cmds, &variantCmd)
+	}
+
+	return cmds
+}
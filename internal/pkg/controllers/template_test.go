@@ -0,0 +1,419 @@
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/api"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/config"
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+var templateClients = []*models.Client{
+	{ID: "cl1", Name: "web1", Hostname: "web1.example.com", OsKernel: "linux", Ipv4: []string{"10.0.0.1"}},
+	{ID: "cl2", Name: "web2", Hostname: "web2.example.com", OsKernel: "linux", Ipv4: []string{"10.0.0.2"}},
+	{ID: "cl3", Name: "db1", Hostname: "db1.example.com", OsKernel: "windows", Ipv4: []string{"10.0.0.3"}},
+}
+
+func startTemplateClientsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/clients", r.URL.Path)
+		assert.Equal(t, "cl1,cl2,cl3", r.URL.Query().Get("filter[id]"))
+		e := json.NewEncoder(rw).Encode(api.ClientsResponse{Data: templateClients})
+		assert.NoError(t, e)
+	}))
+}
+
+func TestRenderTemplate(t *testing.T) {
+	clientsByID := map[string]*models.Client{}
+	for _, c := range templateClients {
+		clientsByID[c.ID] = c
+	}
+
+	testCases := []struct {
+		Name             string
+		Command          string
+		ClientIDs        []string
+		ExpectedVariants []*models.CommandVariant
+		ExpectedError    string
+	}{
+		{
+			Name:      "grouped by identical result",
+			Command:   "install-{{.OsKernel}}.sh",
+			ClientIDs: []string{"cl1", "cl3", "cl2"},
+			ExpectedVariants: []*models.CommandVariant{
+				{ClientIDs: []string{"cl1", "cl2"}, Clients: []string{"web1", "web2"}, Command: "install-linux.sh"},
+				{ClientIDs: []string{"cl3"}, Clients: []string{"db1"}, Command: "install-windows.sh"},
+			},
+		},
+		{
+			Name:      "one variant per client",
+			Command:   "ping {{index .Ipv4 0}}",
+			ClientIDs: []string{"cl1", "cl2"},
+			ExpectedVariants: []*models.CommandVariant{
+				{ClientIDs: []string{"cl1"}, Clients: []string{"web1"}, Command: "ping 10.0.0.1"},
+				{ClientIDs: []string{"cl2"}, Clients: []string{"web2"}, Command: "ping 10.0.0.2"},
+			},
+		},
+		{
+			Name:          "invalid template",
+			Command:       "echo {{.Name",
+			ClientIDs:     []string{"cl1"},
+			ExpectedError: "failed to parse the command as template: template: template:1: unclosed action",
+		},
+		{
+			Name:      "unknown field",
+			Command:   "echo {{.Nickname}}",
+			ClientIDs: []string{"cl1"},
+			ExpectedError: "failed to render the command for client web1 (cl1): template: template:1:7: " +
+				"executing \"template\" at <.Nickname>: can't evaluate field Nickname in type *models.Client",
+		},
+		{
+			Name:          "unknown client",
+			Command:       "echo {{.Name}}",
+			ClientIDs:     []string{"cl1", "cl9"},
+			ExpectedError: "client cl9 not found, the command can't be rendered for it",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			wsCmd := &models.WsScriptCommand{ClientIDs: tc.ClientIDs, Command: tc.Command}
+			variants, err := renderTemplate(wsCmd, tc.Command, clientsByID)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedVariants, variants)
+		})
+	}
+}
+
+func TestBatchCommandsWithScriptVariants(t *testing.T) {
+	wsCmd := &models.WsScriptCommand{
+		ClientIDs:   []string{"cl1", "cl2", "cl3"},
+		Script:      base64.StdEncoding.EncodeToString([]byte("echo {{.Name}}")),
+		Interpreter: "bash",
+	}
+	variants := []*models.CommandVariant{
+		{ClientIDs: []string{"cl1", "cl3"}, Script: "echo web"},
+		{ClientIDs: []string{"cl2"}, Script: "echo db"},
+	}
+
+	cmds := batchCommands(wsCmd, []string{"cl2", "cl3"}, variants)
+
+	require.Len(t, cmds, 2)
+	assert.Equal(t, []string{"cl3"}, cmds[0].ClientIDs)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("echo web")), cmds[0].Script)
+	assert.Equal(t, "bash", cmds[0].Interpreter)
+	assert.Equal(t, []string{"cl2"}, cmds[1].ClientIDs)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("echo db")), cmds[1].Script)
+}
+
+func TestCommandDryRun(t *testing.T) {
+	srv := startTemplateClientsServer(t)
+	defer srv.Close()
+
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		JobRenderer: jr,
+		Rport:       api.New(srv.URL, nil),
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "cl1,cl2,cl3",
+		config.Command:   "hostname",
+		config.DryRun:    "true",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.CommandVariant{
+		{ClientIDs: []string{"cl1", "cl2", "cl3"}, Clients: []string{"web1", "web2", "db1"}, Command: "hostname"},
+	}, jr.variantsToRender)
+	assert.Empty(t, eh.ExecutionResults)
+}
+
+func TestCommandExecutionWithTemplate(t *testing.T) {
+	srv := startTemplateClientsServer(t)
+	defer srv.Close()
+
+	firstRW := makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl1", "cl2"))
+	nextRW := makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl3"))
+	connections := 0
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  firstRW,
+		JobRenderer: jr,
+		Rport:       api.New(srv.URL, nil),
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			assert.True(t, firstRW.isClosed, "without --conc the next variant must not be sent before the first has finished")
+			connections++
+			return nextRW, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs: "cl1,cl2,cl3",
+		config.Command:   "install-{{.OsKernel}}.sh",
+		config.Template:  "true",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, connections)
+	expectedCmds := []*models.WsScriptCommand{
+		{ClientIDs: []string{"cl1", "cl2"}, Command: "install-linux.sh"},
+		{ClientIDs: []string{"cl3"}, Command: "install-windows.sh"},
+	}
+	for i, rw := range []*ReadWriterMock{firstRW, nextRW} {
+		require.Len(t, rw.writtenItems, 1)
+		wsCmd := &models.WsScriptCommand{}
+		require.NoError(t, json.Unmarshal([]byte(rw.writtenItems[0]), wsCmd))
+		assert.Equal(t, expectedCmds[i].ClientIDs, wsCmd.ClientIDs)
+		assert.Equal(t, expectedCmds[i].Command, wsCmd.Command)
+	}
+
+	assert.Len(t, eh.ExecutionResults, 3)
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, 3, jr.summaryToRender.Succeeded)
+}
+
+// inFlightReadWriterMock holds back the jobs until the jobs of all variants are read
+type inFlightReadWriterMock struct {
+	*ReadWriterMock
+	reading     *sync.WaitGroup
+	readStarted sync.Once
+}
+
+func (rw *inFlightReadWriterMock) Read() (msg []byte, err error) {
+	rw.readStarted.Do(rw.reading.Done)
+
+	allReading := make(chan struct{})
+	go func() {
+		rw.reading.Wait()
+		close(allReading)
+	}()
+	select {
+	case <-allReading:
+		return rw.ReadWriterMock.Read()
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("the variants are not read concurrently")
+	}
+}
+
+func TestCommandExecutionWithTemplateRunsVariantsConcurrently(t *testing.T) {
+	srv := startTemplateClientsServer(t)
+	defer srv.Close()
+
+	reading := &sync.WaitGroup{}
+	reading.Add(2)
+	firstRW := &inFlightReadWriterMock{
+		ReadWriterMock: makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusSuccessful, "cl1", "cl2")),
+		reading:        reading,
+	}
+	nextRW := &inFlightReadWriterMock{
+		ReadWriterMock: makeReadWriterMockFromJobs(t, makeBatchJobs(t, models.JobStatusFailed, "cl3")),
+		reading:        reading,
+	}
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  firstRW,
+		JobRenderer: jr,
+		Rport:       api.New(srv.URL, nil),
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			require.Len(t, firstRW.writtenItems, 1, "the jobs of the first variant must not be read before all are sent")
+			return nextRW, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:        "cl1,cl2,cl3",
+		config.Command:          "install-{{.OsKernel}}.sh",
+		config.Template:         "true",
+		config.ExecConcurrently: "true",
+		config.FailThreshold:    "50%",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	assert.True(t, firstRW.isClosed)
+	assert.True(t, nextRW.isClosed)
+	assert.Len(t, eh.ExecutionResults, 3)
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, 2, jr.summaryToRender.Succeeded)
+	assert.Equal(t, 1, jr.summaryToRender.Failed)
+}
+
+func TestCommandExecutionWithTemplateAbortsRemainingVariants(t *testing.T) {
+	srv := startTemplateClientsServer(t)
+	defer srv.Close()
+
+	jobs := makeBatchJobs(t, models.JobStatusFailed, "cl1")
+	jobs = append(jobs, makeBatchJobs(t, models.JobStatusSuccessful, "cl2")...)
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  makeReadWriterMockFromJobs(t, jobs),
+		JobRenderer: jr,
+		Rport:       api.New(srv.URL, nil),
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			t.Fatal("no further variant expected after the failed one with --abort")
+			return nil, nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:    "cl1,cl2,cl3",
+		config.Command:      "install-{{.OsKernel}}.sh",
+		config.Template:     "true",
+		config.AbortOnError: "true",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	assertExitCode(t, err, ExitCodeSomeFailed)
+	assert.EqualError(t, err, "failed on 2 of 3 clients")
+
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, []*models.SummaryClient{{ID: "cl3", Name: "db1"}}, jr.summaryToRender.NotReported)
+}
+
+// connectionsTracker counts the connections open at the same time
+type connectionsTracker struct {
+	mu      sync.Mutex
+	open    int
+	maxOpen int
+}
+
+func (ct *connectionsTracker) connect(t *testing.T) *trackedReadWriterMock {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.open++
+	if ct.open > ct.maxOpen {
+		ct.maxOpen = ct.open
+	}
+
+	return &trackedReadWriterMock{t: t, tracker: ct, msgs: make(chan []byte, 1)}
+}
+
+// trackedReadWriterMock returns a successful job for the client of the command written to it
+type trackedReadWriterMock struct {
+	t       *testing.T
+	tracker *connectionsTracker
+	msgs    chan []byte
+	closed  bool
+}
+
+func (rw *trackedReadWriterMock) Write(inputMsg []byte) (n int, err error) {
+	wsCmd := &models.WsScriptCommand{}
+	require.NoError(rw.t, json.Unmarshal(inputMsg, wsCmd))
+	jobBytes, err := json.Marshal(makeBatchJobs(rw.t, models.JobStatusSuccessful, wsCmd.ClientIDs[0])[0])
+	require.NoError(rw.t, err)
+	rw.msgs <- jobBytes
+	close(rw.msgs)
+
+	return len(inputMsg), nil
+}
+
+func (rw *trackedReadWriterMock) Read() (msg []byte, err error) {
+	// keeps the variants in flight long enough to overlap
+	time.Sleep(10 * time.Millisecond)
+	msg, ok := <-rw.msgs
+	if !ok {
+		return nil, io.EOF
+	}
+	return msg, nil
+}
+
+func (rw *trackedReadWriterMock) Close() error {
+	rw.tracker.mu.Lock()
+	defer rw.tracker.mu.Unlock()
+
+	if !rw.closed {
+		rw.closed = true
+		rw.tracker.open--
+	}
+	return nil
+}
+
+func TestCommandExecutionWithTemplateLimitsConcurrentVariants(t *testing.T) {
+	clients := make([]*models.Client, 0)
+	clientIDs := make([]string, 0)
+	for i := 1; i <= maxVariantConnections+5; i++ {
+		id := fmt.Sprintf("cl%d", i)
+		clients = append(clients, &models.Client{ID: id, Name: "web" + id})
+		clientIDs = append(clientIDs, id)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(rw).Encode(api.ClientsResponse{Data: clients}))
+	}))
+	defer srv.Close()
+
+	tracker := &connectionsTracker{}
+	jr := &JobRendererMock{}
+	eh := &ExecutionHelper{
+		ReadWriter:  tracker.connect(t),
+		JobRenderer: jr,
+		Rport:       api.New(srv.URL, nil),
+		NewReadWriter: func(ctx context.Context) (ReadWriter, error) {
+			return tracker.connect(t), nil
+		},
+	}
+	cc := &CommandsController{
+		ExecutionHelper: eh,
+	}
+
+	params := config.FromValues(map[string]string{
+		config.ClientIDs:        strings.Join(clientIDs, ","),
+		config.Command:          "ping {{.Name}}",
+		config.Template:         "true",
+		config.ExecConcurrently: "true",
+	})
+	err := cc.Start(context.Background(), params, nil, nil)
+	require.NoError(t, err)
+
+	assert.Greater(t, tracker.maxOpen, 1)
+	assert.LessOrEqual(t, tracker.maxOpen, maxVariantConnections)
+	assert.Equal(t, 0, tracker.open)
+	require.NotNil(t, jr.summaryToRender)
+	assert.Equal(t, len(clients), jr.summaryToRender.Succeeded)
+}
+
+func TestCheckTemplateParams(t *testing.T) {
+	err := checkTemplateParams(config.FromValues(map[string]string{
+		config.Template: "true",
+		config.GroupIDs: "g1",
+	}))
+	assert.EqualError(t, err, "--template can't be used with --gids, target the clients by ids, names or search")
+
+	err = checkTemplateParams(config.FromValues(map[string]string{
+		config.Template: "true",
+		config.Detach:   "true",
+	}))
+	assert.EqualError(t, err, "--template can't be used with --detach")
+}
@@ -65,8 +65,12 @@ func (rwm *ReadWriterMock) Close() error {
 }
 
 type JobRendererMock struct {
-	jobToRender *models.Job
-	err         error
+	jobToRender        *models.Job
+	jobStartedToRender *models.JobStarted
+	flushed            bool
+	summaryToRender    *models.ExecutionSummary
+	variantsToRender   []*models.CommandVariant
+	err                error
 }
 
 func (jrm *JobRendererMock) RenderJob(j *models.Job) error {
@@ -74,6 +78,26 @@ func (jrm *JobRendererMock) RenderJob(j *models.Job) error {
 	return jrm.err
 }
 
+func (jrm *JobRendererMock) RenderJobStarted(js *models.JobStarted) error {
+	jrm.jobStartedToRender = js
+	return jrm.err
+}
+
+func (jrm *JobRendererMock) Flush() error {
+	jrm.flushed = true
+	return nil
+}
+
+func (jrm *JobRendererMock) RenderSummary(es *models.ExecutionSummary) error {
+	jrm.summaryToRender = es
+	return nil
+}
+
+func (jrm *JobRendererMock) RenderCommandVariants(variants []*models.CommandVariant) error {
+	jrm.variantsToRender = variants
+	return jrm.err
+}
+
 func ReadJobsFromYAML(sourceJobsFilename string) (prevExecutionLogInfo *ExecutionLogInfo, err error) {
 	fileContents, err := os.ReadFile(sourceJobsFilename)
 	if err != nil {
@@ -37,6 +37,7 @@ type TunnelController struct {
 	Rport          *api.Rport
 	TunnelRenderer TunnelRenderer
 	IPProvider     IPProvider
+	ClientPicker   ClientPicker
 }
 
 func (tc *TunnelController) Tunnels(ctx context.Context, params *options.ParameterBag) error {
@@ -45,15 +46,10 @@ func (tc *TunnelController) Tunnels(ctx context.Context, params *options.Paramet
 		"name", params.ReadString(config.ClientNameFlag, ""),
 		"*", params.ReadString(config.ClientSearchFlag, ""),
 	)
-	clResp, err := tc.Rport.Clients(
-		ctx,
-		api.NewPaginationFromParams(params),
-		filter,
-	)
+	clients, err := tc.Rport.AllClients(ctx, filter)
 	if err != nil {
 		return err
 	}
-	clients := clResp.Data
 
 	tunnels := make([]*models.Tunnel, 0)
 	for _, cl := range clients {
@@ -108,34 +104,10 @@ func (tc *TunnelController) getClientIDAndClientName(
 	if clientID != "" {
 		return
 	}
-	maxClientsForSelection := 25
-	clients, err := tc.Rport.Clients(ctx, api.NewPaginationWithLimit(maxClientsForSelection), api.NewFilters("name", clientName))
+	client, err := findClientByName(ctx, tc.Rport, clientName, pickerUnlessNoPrompt(tc.ClientPicker, params))
 	if err != nil {
 		return "", "", err
 	}
-	var client *models.Client
-	numClients := len(clients.Data)
-
-	switch {
-	case numClients == 1:
-		client = clients.Data[0]
-	case numClients < 1:
-		return "", "", fmt.Errorf("unknown client with name %q", clientName)
-	case numClients > maxClientsForSelection:
-		return "", "", fmt.Errorf(
-			"client with name %q is ambiguous, use a more precise name or use the client id", clientName,
-		)
-	default:
-		names := []string{}
-		for _, client := range clients.Data {
-			names = append(names, "- "+client.Name)
-		}
-		return "", "", fmt.Errorf(
-			"client with name %q is ambiguous, use a more precise name or use the client id.\ndo you mean:\n%s",
-			clientName,
-			strings.Join(names, "\n"),
-		)
-	}
 
 	return client.ID, client.Name, nil
 }
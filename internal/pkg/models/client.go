@@ -13,6 +13,11 @@ import (
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/utils"
 )
 
+const (
+	ClientConnStateConnected    = "connected"
+	ClientConnStateDisconnected = "disconnected"
+)
+
 type UpdatesStatus struct {
 	Refreshed                time.Time       `json:"refreshed"`
 	UpdatesAvailable         int             `json:"updates_available"`
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/breathbath/go_utils/v2/pkg/testing"
+)
+
+// ClientAuth are the credentials rport clients use to connect to the server
+type ClientAuth struct {
+	ID       string `json:"id" yaml:"id"`
+	Password string `json:"password" yaml:"password"`
+}
+
+func (ca *ClientAuth) Headers() []string {
+	return []string{
+		"ID",
+		"PASSWORD",
+	}
+}
+
+func (ca *ClientAuth) Row() []string {
+	return []string{
+		ca.ID,
+		ca.Password,
+	}
+}
+
+// ClientAuthConfig are newly created client auth credentials together with the client config to use them
+type ClientAuthConfig struct {
+	ClientAuth `yaml:",inline"`
+	Server     string `json:"server" yaml:"server"`
+	RportConf  string `json:"rport_conf" yaml:"rport_conf"`
+}
+
+// NewClientAuthConfig creates the rport.conf snippet connecting a client to the server with the given credentials
+func NewClientAuthConfig(auth ClientAuth, server string) *ClientAuthConfig {
+	return &ClientAuthConfig{
+		ClientAuth: auth,
+		Server:     server,
+		RportConf: fmt.Sprintf(
+			"[client]\n  server = %q\n  auth = %q\n",
+			server,
+			auth.ID+":"+auth.Password,
+		),
+	}
+}
+
+func (cac *ClientAuthConfig) KeyValues() []testing.KeyValueStr {
+	return []testing.KeyValueStr{
+		{
+			Key:   "ID",
+			Value: cac.ID,
+		},
+		{
+			Key:   "Password",
+			Value: cac.Password,
+		},
+		{
+			Key:   "Server",
+			Value: cac.Server,
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ClientEventConnected    = "connected"
+	ClientEventDisconnected = "disconnected"
+	ClientEventNew          = "new"
+	ClientEventRemoved      = "removed"
+	ClientEventChanged      = "changed"
+)
+
+// ClientFieldChange describes the change of a single client field between two observations
+type ClientFieldChange struct {
+	Field string `json:"field" yaml:"field"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+// ClientEvent is emitted when a watched client connects, disconnects, appears, disappears or changes its address
+type ClientEvent struct {
+	Time           time.Time           `json:"time" yaml:"time"`
+	Type           string              `json:"type" yaml:"type"`
+	ClientID       string              `json:"client_id" yaml:"client_id"`
+	ClientName     string              `json:"client_name" yaml:"client_name"`
+	ConnState      string              `json:"connection_state" yaml:"connection_state"`
+	DisconnectedAt string              `json:"disconnected_at" yaml:"disconnected_at"`
+	Address        string              `json:"address" yaml:"address"`
+	Changes        []ClientFieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
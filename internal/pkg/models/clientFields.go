@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// ClientFieldNames returns the json keys of all client fields in the order of their declaration
+func ClientFieldNames() []string {
+	clientType := reflect.TypeOf(Client{})
+	names := make([]string, 0, clientType.NumField())
+	for i := 0; i < clientType.NumField(); i++ {
+		names = append(names, jsonFieldName(clientType.Field(i)))
+	}
+
+	return names
+}
+
+// IsClientField tells if the name is a json key of a client field
+func IsClientField(name string) bool {
+	for _, fieldName := range ClientFieldNames() {
+		if fieldName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// FieldValue returns the value of the field identified by its json key, nil is returned for unknown fields
+func (c *Client) FieldValue(name string) interface{} {
+	clientValue := reflect.ValueOf(c).Elem()
+	for i := 0; i < clientValue.NumField(); i++ {
+		if jsonFieldName(clientValue.Type().Field(i)) == name {
+			return clientValue.Field(i).Interface()
+		}
+	}
+
+	return nil
+}
+
+// FormatFieldValue renders the value of the field identified by its json key for a human readable table
+func (c *Client) FormatFieldValue(name string) string {
+	switch value := c.FieldValue(name).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []string:
+		return strings.Join(value, ", ")
+	case []*Tunnel:
+		return strconv.Itoa(len(value))
+	case uint64:
+		if name == "mem_total" {
+			return humanize.Bytes(value)
+		}
+		return strconv.FormatUint(value, 10)
+	case *UpdatesStatus:
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d (%d security)", value.UpdatesAvailable, value.SecurityUpdatesAvailable)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// ClientWithFields renders only the selected fields of a client as a table row
+type ClientWithFields struct {
+	*Client
+	Fields []string
+}
+
+func (cf *ClientWithFields) Headers() []string {
+	headers := make([]string, 0, len(cf.Fields))
+	for _, field := range cf.Fields {
+		headers = append(headers, strings.ToUpper(field))
+	}
+
+	return headers
+}
+
+func (cf *ClientWithFields) Row() []string {
+	row := make([]string, 0, len(cf.Fields))
+	for _, field := range cf.Fields {
+		row = append(row, cf.Client.FormatFieldValue(field))
+	}
+
+	return row
+}
+
+// SelectedFields returns the selected fields of a client keyed by their json names, used for json and yaml output
+func (cf *ClientWithFields) SelectedFields() map[string]interface{} {
+	selected := make(map[string]interface{}, len(cf.Fields))
+	for _, field := range cf.Fields {
+		selected[field] = cf.Client.FieldValue(field)
+	}
+
+	return selected
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/breathbath/go_utils/v2/pkg/testing"
+)
+
+// ClientGroupParams maps a client attribute, e.g. os_family, to the list of values a client must match
+// to be a member of the group. Values support wildcards (*).
+type ClientGroupParams map[string][]string
+
+// Pairs renders the params in the same key=value syntax as accepted by the --search flag
+func (cgp ClientGroupParams) Pairs() []string {
+	keys := make([]string, 0, len(cgp))
+	for key, values := range cgp {
+		// the server returns all supported params, including the ones not used by the group
+		if len(values) == 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, strings.Join(cgp[key], ",")))
+	}
+
+	return pairs
+}
+
+func (cgp ClientGroupParams) String() string {
+	return strings.Join(cgp.Pairs(), " ")
+}
+
+type ClientGroup struct {
+	ID          string            `json:"id"`
+	Description string            `json:"description"`
+	Params      ClientGroupParams `json:"params"`
+	ClientIDs   []string          `json:"client_ids,omitempty"`
+}
+
+func (cg *ClientGroup) Headers() []string {
+	return []string{
+		"ID",
+		"DESCRIPTION",
+		"CLIENTS",
+		"PARAMS",
+	}
+}
+
+func (cg *ClientGroup) Row() []string {
+	return []string{
+		cg.ID,
+		cg.Description,
+		strconv.Itoa(len(cg.ClientIDs)),
+		cg.Params.String(),
+	}
+}
+
+func (cg *ClientGroup) KeyValues() []testing.KeyValueStr {
+	return []testing.KeyValueStr{
+		{
+			Key:   "ID",
+			Value: cg.ID,
+		},
+		{
+			Key:   "Description",
+			Value: cg.Description,
+		},
+		{
+			Key:   "Params",
+			Value: strings.Join(cg.Params.Pairs(), "\n"),
+		},
+		{
+			Key:   "Client IDs",
+			Value: strings.Join(cg.ClientIDs, "\n"),
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// ClientMetrics is a single measurement of the resource usage of a client
+type ClientMetrics struct {
+	Timestamp          time.Time `json:"timestamp" yaml:"timestamp"`
+	CPUUsagePercent    float64   `json:"cpu_usage_percent" yaml:"cpu_usage_percent"`
+	MemoryUsagePercent float64   `json:"memory_usage_percent" yaml:"memory_usage_percent"`
+	IOUsagePercent     float64   `json:"io_usage_percent" yaml:"io_usage_percent"`
+}
+
+// ClientMetricsReport are the measurements of a client within a time range
+type ClientMetricsReport struct {
+	ClientID   string           `json:"client_id" yaml:"client_id"`
+	ClientName string           `json:"client_name,omitempty" yaml:"client_name,omitempty"`
+	Since      time.Time        `json:"since" yaml:"since"`
+	Until      time.Time        `json:"until" yaml:"until"`
+	Metrics    []*ClientMetrics `json:"metrics" yaml:"metrics"`
+}
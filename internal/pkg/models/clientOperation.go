@@ -0,0 +1,27 @@
+package models
+
+// ClientOperationResult is the outcome of an operation like a deletion or a refresh on a single client
+type ClientOperationResult struct {
+	ClientID   string `json:"client_id" yaml:"client_id"`
+	ClientName string `json:"client_name" yaml:"client_name"`
+	Status     string `json:"status" yaml:"status"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (cor *ClientOperationResult) Headers() []string {
+	return []string{
+		"ID",
+		"NAME",
+		"STATUS",
+		"ERROR",
+	}
+}
+
+func (cor *ClientOperationResult) Row() []string {
+	return []string{
+		cor.ClientID,
+		cor.ClientName,
+		cor.Status,
+		cor.Error,
+	}
+}
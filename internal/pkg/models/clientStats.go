@@ -0,0 +1,67 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// ClientStatsGroup is the number of clients sharing the same values of the aggregated fields
+type ClientStatsGroup struct {
+	Fields  map[string]string `json:"fields" yaml:"fields"`
+	Count   int               `json:"count" yaml:"count"`
+	Percent float64           `json:"percent" yaml:"percent"`
+}
+
+// ClientStats are the clients counted by the values of the given fields
+type ClientStats struct {
+	By     []string            `json:"by" yaml:"by"`
+	Total  int                 `json:"total" yaml:"total"`
+	Groups []*ClientStatsGroup `json:"groups" yaml:"groups"`
+}
+
+// NewClientStats counts the clients by the formatted values of the given fields,
+// the groups are sorted by count in descending order
+func NewClientStats(clients []*Client, by []string) *ClientStats {
+	groupsByKey := make(map[string]*ClientStatsGroup)
+	groups := make([]*ClientStatsGroup, 0)
+	for _, cl := range clients {
+		values := make([]string, 0, len(by))
+		for _, field := range by {
+			values = append(values, cl.FormatFieldValue(field))
+		}
+
+		key := strings.Join(values, "\x00")
+		group, found := groupsByKey[key]
+		if !found {
+			group = &ClientStatsGroup{Fields: make(map[string]string, len(by))}
+			for i, field := range by {
+				group.Fields[field] = values[i]
+			}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Count++
+	}
+
+	for _, group := range groups {
+		group.Percent = float64(group.Count) * 100 / float64(len(clients))
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		for _, field := range by {
+			if groups[i].Fields[field] != groups[j].Fields[field] {
+				return groups[i].Fields[field] < groups[j].Fields[field]
+			}
+		}
+		return false
+	})
+
+	return &ClientStats{
+		By:     by,
+		Total:  len(clients),
+		Groups: groups,
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// ClientUpdates is the update status of a single client as shown in the fleet updates report
+type ClientUpdates struct {
+	ClientID                 string     `json:"client_id" yaml:"client_id"`
+	ClientName               string     `json:"client_name" yaml:"client_name"`
+	UpdatesAvailable         int        `json:"updates_available" yaml:"updates_available"`
+	SecurityUpdatesAvailable int        `json:"security_updates_available" yaml:"security_updates_available"`
+	RebootPending            bool       `json:"reboot_pending" yaml:"reboot_pending"`
+	Refreshed                *time.Time `json:"refreshed,omitempty" yaml:"refreshed,omitempty"`
+	Error                    string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewClientUpdates creates the update status of a client, clients which never reported updates have no refresh time
+func NewClientUpdates(cl *Client) *ClientUpdates {
+	cu := &ClientUpdates{
+		ClientID:   cl.ID,
+		ClientName: cl.Name,
+	}
+	if cl.UpdatesStatus == nil {
+		return cu
+	}
+
+	cu.UpdatesAvailable = cl.UpdatesStatus.UpdatesAvailable
+	cu.SecurityUpdatesAvailable = cl.UpdatesStatus.SecurityUpdatesAvailable
+	cu.RebootPending = cl.UpdatesStatus.RebootPending
+	cu.Error = cl.UpdatesStatus.Error
+	if !cl.UpdatesStatus.Refreshed.IsZero() {
+		refreshed := cl.UpdatesStatus.Refreshed
+		cu.Refreshed = &refreshed
+	}
+
+	return cu
+}
+
+func (cu *ClientUpdates) Headers() []string {
+	return []string{
+		"ID",
+		"NAME",
+		"UPDATES",
+		"SECURITY UPDATES",
+		"REBOOT PENDING",
+		"REFRESHED",
+		"ERROR",
+	}
+}
+
+func (cu *ClientUpdates) Row() []string {
+	refreshed := ""
+	if cu.Refreshed != nil {
+		refreshed = cu.Refreshed.Format(time.RFC3339)
+	}
+
+	return []string{
+		cu.ClientID,
+		cu.ClientName,
+		strconv.Itoa(cu.UpdatesAvailable),
+		strconv.Itoa(cu.SecurityUpdatesAvailable),
+		strconv.FormatBool(cu.RebootPending),
+		refreshed,
+		cu.Error,
+	}
+}
@@ -1,12 +1,22 @@
 package models
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/breathbath/go_utils/v2/pkg/testing"
 )
 
+const (
+	JobStatusSuccessful = "successful"
+	JobStatusFailed     = "failed"
+	JobStatusRunning    = "running"
+	// the server sets the status of jobs which haven't finished within the timeout to unknown
+	JobStatusUnknown = "unknown"
+)
+
 type JobResult struct {
 	Stdout string `json:"stdout"`
 	Stderr string `json:"stderr"`
@@ -30,6 +40,29 @@ type Job struct {
 	IsSudo      bool      `json:"is_sudo" yaml:"is_sudo"`
 	IsScript    bool      `json:"is_script" yaml:"is_script"`
 	Interpreter string    `json:"interpreter" yaml:"interpreter"`
+	// Attempt is the number of the execution the job belongs to when failed jobs are retried
+	Attempt int `json:"attempt,omitempty" yaml:"attempt,omitempty"`
+}
+
+// CommandVariant is the command or script rendered by --template for a group of clients with identical results
+type CommandVariant struct {
+	ClientIDs []string `json:"client_ids" yaml:"client_ids"`
+	Clients   []string `json:"clients" yaml:"clients"`
+	Command   string   `json:"command,omitempty" yaml:"command,omitempty"`
+	Script    string   `json:"script,omitempty" yaml:"script,omitempty"`
+}
+
+// JobGroup is the status and output shared by the jobs of several clients
+type JobGroup struct {
+	Clients []string  `json:"clients" yaml:"clients"`
+	Status  string    `json:"status" yaml:"status"`
+	Error   string    `json:"error" yaml:"error"`
+	Result  JobResult `json:"result" yaml:"result"`
+}
+
+// JobStarted identifies a multi-client job started without waiting for its results
+type JobStarted struct {
+	Jid string `json:"jid" yaml:"jid"`
 }
 
 type WsScriptCommand struct {
@@ -101,3 +134,165 @@ func (j *Job) KeyValues() []testing.KeyValueStr {
 		},
 	}
 }
+
+func (j *Job) Headers() []string {
+	return []string{
+		"JOB ID",
+		"STATUS",
+		"CLIENT",
+		"STARTED AT",
+		"FINISHED AT",
+		"CREATED BY",
+		"COMMAND",
+	}
+}
+
+func (j *Job) Row() []string {
+	client := j.ClientName
+	if client == "" {
+		client = j.ClientID
+	}
+
+	return []string{
+		j.Jid,
+		j.Status,
+		client,
+		formatJobTime(j.StartedAt),
+		formatJobTime(j.FinishedAt),
+		j.CreatedBy,
+		j.Command,
+	}
+}
+
+// MultiJob is a command or script executed on several clients at once, Jobs holds the results of each client
+type MultiJob struct {
+	Jid         string    `json:"jid" yaml:"jid"`
+	StartedAt   time.Time `json:"started_at" yaml:"started_at"`
+	CreatedBy   string    `json:"created_by" yaml:"created_by"`
+	ClientIDs   []string  `json:"client_ids" yaml:"client_ids"`
+	GroupIDs    []string  `json:"group_ids,omitempty" yaml:"group_ids,omitempty"`
+	Command     string    `json:"command" yaml:"command"`
+	Interpreter string    `json:"interpreter" yaml:"interpreter"`
+	Cwd         string    `json:"cwd" yaml:"cwd"`
+	IsSudo      bool      `json:"is_sudo" yaml:"is_sudo"`
+	IsScript    bool      `json:"is_script" yaml:"is_script"`
+	TimeoutSec  int       `json:"timeout_sec" yaml:"timeout_sec"`
+	Concurrent  bool      `json:"concurrent" yaml:"concurrent"`
+	AbortOnErr  bool      `json:"abort_on_err" yaml:"abort_on_err"`
+	Jobs        []*Job    `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+}
+
+func (mj *MultiJob) Headers() []string {
+	return []string{
+		"JOB ID",
+		"STARTED AT",
+		"CREATED BY",
+		"CLIENTS",
+		"COMMAND",
+	}
+}
+
+func (mj *MultiJob) Row() []string {
+	return []string{
+		mj.Jid,
+		formatJobTime(mj.StartedAt),
+		mj.CreatedBy,
+		strconv.Itoa(len(mj.ClientIDs)),
+		mj.Command,
+	}
+}
+
+// the keys of the statuses in the execution summary
+const (
+	SummarySucceeded   = "Succeeded"
+	SummaryFailed      = "Failed"
+	SummaryTimedOut    = "Timed out"
+	SummaryNotReported = "Not reported back"
+)
+
+// ExecutionSummary sums up the jobs of a multi-client execution, NotReported are the targeted clients
+// which haven't sent a finished job
+type ExecutionSummary struct {
+	Targeted       int              `json:"targeted" yaml:"targeted"`
+	Finished       int              `json:"finished" yaml:"finished"`
+	Succeeded      int              `json:"succeeded" yaml:"succeeded"`
+	Failed         int              `json:"failed" yaml:"failed"`
+	TimedOut       int              `json:"timed_out" yaml:"timed_out"`
+	NotReported    []*SummaryClient `json:"not_reported" yaml:"not_reported"`
+	MinDurationSec float64          `json:"min_duration_sec" yaml:"min_duration_sec"`
+	AvgDurationSec float64          `json:"avg_duration_sec" yaml:"avg_duration_sec"`
+	MaxDurationSec float64          `json:"max_duration_sec" yaml:"max_duration_sec"`
+}
+
+// SummaryClient is a client named in the execution summary, the name is empty if it isn't known
+type SummaryClient struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+func (sc *SummaryClient) String() string {
+	if sc.Name == "" {
+		return sc.ID
+	}
+	return fmt.Sprintf("%s (%s)", sc.Name, sc.ID)
+}
+
+func (es *ExecutionSummary) KeyValues() []testing.KeyValueStr {
+	notReported := strconv.Itoa(len(es.NotReported))
+	if len(es.NotReported) > 0 {
+		clients := make([]string, 0, len(es.NotReported))
+		for _, c := range es.NotReported {
+			clients = append(clients, c.String())
+		}
+		notReported += ": " + strings.Join(clients, ", ")
+	}
+	durations := fmt.Sprintf(
+		"%s / %s / %s",
+		formatSeconds(es.MinDurationSec),
+		formatSeconds(es.AvgDurationSec),
+		formatSeconds(es.MaxDurationSec),
+	)
+
+	return []testing.KeyValueStr{
+		{
+			Key:   "Targeted",
+			Value: strconv.Itoa(es.Targeted),
+		},
+		{
+			Key:   "Finished",
+			Value: strconv.Itoa(es.Finished),
+		},
+		{
+			Key:   SummarySucceeded,
+			Value: strconv.Itoa(es.Succeeded),
+		},
+		{
+			Key:   SummaryFailed,
+			Value: strconv.Itoa(es.Failed),
+		},
+		{
+			Key:   SummaryTimedOut,
+			Value: strconv.Itoa(es.TimedOut),
+		},
+		{
+			Key:   SummaryNotReported,
+			Value: notReported,
+		},
+		{
+			Key:   "Duration min/avg/max",
+			Value: durations,
+		},
+	}
+}
+
+func formatSeconds(sec float64) string {
+	return time.Duration(sec * float64(time.Second)).Round(time.Millisecond).String()
+}
+
+// formatJobTime leaves the time of unfinished jobs empty instead of rendering the zero time
+func formatJobTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/breathbath/go_utils/v2/pkg/testing"
+)
+
+// ExecLogHeader tells who executed the jobs of an execution log, when and against which server
+type ExecLogHeader struct {
+	ExecutedAt time.Time `json:"executed_at" yaml:"executed_at"`
+	ExecutedBy string    `json:"executed_by" yaml:"executed_by"`
+	ExecutedOn string    `json:"executed_on" yaml:"executed_on"`
+	APIUser    string    `json:"api_user" yaml:"api_user"`
+	APIURL     string    `json:"api_url" yaml:"api_url"`
+	APIAuth    string    `json:"api_auth" yaml:"api_auth"`
+	NumClients int       `json:"num_clients" yaml:"num_clients"`
+	Failed     int       `json:"failed" yaml:"failed"`
+}
+
+func (h *ExecLogHeader) KeyValues() []testing.KeyValueStr {
+	return []testing.KeyValueStr{
+		{
+			Key:   "Executed at",
+			Value: h.ExecutedAt.Format(time.RFC3339),
+		},
+		{
+			Key:   "Executed by",
+			Value: h.ExecutedBy,
+		},
+		{
+			Key:   "Executed on",
+			Value: h.ExecutedOn,
+		},
+		{
+			Key:   "API user",
+			Value: h.APIUser,
+		},
+		{
+			Key:   "API URL",
+			Value: h.APIURL,
+		},
+		{
+			Key:   "API auth",
+			Value: h.APIAuth,
+		},
+		{
+			Key:   "Clients",
+			Value: strconv.Itoa(h.NumClients),
+		},
+		{
+			Key:   "Failed",
+			Value: strconv.Itoa(h.Failed),
+		},
+	}
+}
+
+// ExecLogDiff is the difference of the job of a client between two execution logs, the status is empty
+// if the client isn't in one of the logs, the output differences are unified diffs
+type ExecLogDiff struct {
+	ClientID     string `json:"client_id" yaml:"client_id"`
+	ClientName   string `json:"client_name" yaml:"client_name"`
+	StatusBefore string `json:"status_before" yaml:"status_before"`
+	StatusAfter  string `json:"status_after" yaml:"status_after"`
+	StdoutDiff   string `json:"stdout_diff,omitempty" yaml:"stdout_diff,omitempty"`
+	StderrDiff   string `json:"stderr_diff,omitempty" yaml:"stderr_diff,omitempty"`
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/breathbath/go_utils/v2/pkg/testing"
+)
+
+// StoredCommand is a command saved in the command library of the server
+type StoredCommand struct {
+	ID          string    `json:"id" yaml:"id"`
+	Name        string    `json:"name" yaml:"name"`
+	Cmd         string    `json:"cmd" yaml:"cmd"`
+	Interpreter string    `json:"interpreter,omitempty" yaml:"interpreter,omitempty"`
+	CreatedBy   string    `json:"created_by" yaml:"created_by"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedBy   string    `json:"updated_by" yaml:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+func (sc *StoredCommand) Headers() []string {
+	return []string{
+		"ID",
+		"NAME",
+		"UPDATED BY",
+		"UPDATED AT",
+		"COMMAND",
+	}
+}
+
+func (sc *StoredCommand) Row() []string {
+	return []string{
+		sc.ID,
+		sc.Name,
+		sc.UpdatedBy,
+		formatJobTime(sc.UpdatedAt),
+		sc.Cmd,
+	}
+}
+
+func (sc *StoredCommand) KeyValues() []testing.KeyValueStr {
+	return []testing.KeyValueStr{
+		{
+			Key:   "ID",
+			Value: sc.ID,
+		},
+		{
+			Key:   "Name",
+			Value: sc.Name,
+		},
+		{
+			Key:   "Interpreter",
+			Value: sc.Interpreter,
+		},
+		{
+			Key:   "Created By",
+			Value: sc.CreatedBy,
+		},
+		{
+			Key:   "Created At",
+			Value: formatJobTime(sc.CreatedAt),
+		},
+		{
+			Key:   "Updated By",
+			Value: sc.UpdatedBy,
+		},
+		{
+			Key:   "Updated At",
+			Value: formatJobTime(sc.UpdatedAt),
+		},
+		{
+			Key:   "Command",
+			Value: sc.Cmd,
+		},
+	}
+}
+
+// StoredScript is a script saved in the script library of the server
+type StoredScript struct {
+	ID          string    `json:"id" yaml:"id"`
+	Name        string    `json:"name" yaml:"name"`
+	Script      string    `json:"script" yaml:"script"`
+	Interpreter string    `json:"interpreter" yaml:"interpreter"`
+	Cwd         string    `json:"cwd" yaml:"cwd"`
+	IsSudo      bool      `json:"is_sudo" yaml:"is_sudo"`
+	CreatedBy   string    `json:"created_by" yaml:"created_by"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedBy   string    `json:"updated_by" yaml:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+func (ss *StoredScript) Headers() []string {
+	return []string{
+		"ID",
+		"NAME",
+		"INTERPRETER",
+		"UPDATED BY",
+		"UPDATED AT",
+	}
+}
+
+func (ss *StoredScript) Row() []string {
+	return []string{
+		ss.ID,
+		ss.Name,
+		ss.Interpreter,
+		ss.UpdatedBy,
+		formatJobTime(ss.UpdatedAt),
+	}
+}
+
+// KeyValues lists the script properties, the script itself is rendered separately as it usually spans several lines
+func (ss *StoredScript) KeyValues() []testing.KeyValueStr {
+	return []testing.KeyValueStr{
+		{
+			Key:   "ID",
+			Value: ss.ID,
+		},
+		{
+			Key:   "Name",
+			Value: ss.Name,
+		},
+		{
+			Key:   "Interpreter",
+			Value: ss.Interpreter,
+		},
+		{
+			Key:   "Cwd",
+			Value: ss.Cwd,
+		},
+		{
+			Key:   "Is sudo",
+			Value: strconv.FormatBool(ss.IsSudo),
+		},
+		{
+			Key:   "Created By",
+			Value: ss.CreatedBy,
+		},
+		{
+			Key:   "Created At",
+			Value: formatJobTime(ss.CreatedAt),
+		},
+		{
+			Key:   "Updated By",
+			Value: ss.UpdatedBy,
+		},
+		{
+			Key:   "Updated At",
+			Value: formatJobTime(ss.UpdatedAt),
+		},
+	}
+}
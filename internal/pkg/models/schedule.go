@@ -0,0 +1,128 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/breathbath/go_utils/v2/pkg/testing"
+)
+
+const ScheduleTypeCommand = "command"
+
+// Schedule is a command the server executes on the targeted clients whenever the cron expression fires
+type Schedule struct {
+	ID                  string    `json:"id" yaml:"id"`
+	Name                string    `json:"name" yaml:"name"`
+	Schedule            string    `json:"schedule" yaml:"schedule"`
+	Type                string    `json:"type" yaml:"type"`
+	ClientIDs           []string  `json:"client_ids" yaml:"client_ids"`
+	GroupIDs            []string  `json:"group_ids,omitempty" yaml:"group_ids,omitempty"`
+	Command             string    `json:"command" yaml:"command"`
+	Interpreter         string    `json:"interpreter" yaml:"interpreter"`
+	Cwd                 string    `json:"cwd" yaml:"cwd"`
+	IsSudo              bool      `json:"is_sudo" yaml:"is_sudo"`
+	TimeoutSec          int       `json:"timeout_sec" yaml:"timeout_sec"`
+	ExecuteConcurrently bool      `json:"execute_concurrently" yaml:"execute_concurrently"`
+	AbortOnError        bool      `json:"abort_on_error" yaml:"abort_on_error"`
+	CreatedBy           string    `json:"created_by" yaml:"created_by"`
+	CreatedAt           time.Time `json:"created_at" yaml:"created_at"`
+}
+
+func (s *Schedule) Headers() []string {
+	return []string{
+		"ID",
+		"NAME",
+		"SCHEDULE",
+		"CLIENTS",
+		"COMMAND",
+	}
+}
+
+func (s *Schedule) Row() []string {
+	return []string{
+		s.ID,
+		s.Name,
+		s.Schedule,
+		strconv.Itoa(len(s.ClientIDs)),
+		s.Command,
+	}
+}
+
+func (s *Schedule) KeyValues() []testing.KeyValueStr {
+	return []testing.KeyValueStr{
+		{
+			Key:   "ID",
+			Value: s.ID,
+		},
+		{
+			Key:   "Name",
+			Value: s.Name,
+		},
+		{
+			Key:   "Schedule",
+			Value: s.Schedule,
+		},
+		{
+			Key:   "Client IDs",
+			Value: strings.Join(s.ClientIDs, ", "),
+		},
+		{
+			Key:   "Group IDs",
+			Value: strings.Join(s.GroupIDs, ", "),
+		},
+		{
+			Key:   "Command",
+			Value: s.Command,
+		},
+		{
+			Key:   "Interpreter",
+			Value: s.Interpreter,
+		},
+		{
+			Key:   "Cwd",
+			Value: s.Cwd,
+		},
+		{
+			Key:   "Is sudo",
+			Value: strconv.FormatBool(s.IsSudo),
+		},
+		{
+			Key:   "Timeout sec",
+			Value: strconv.Itoa(s.TimeoutSec),
+		},
+		{
+			Key:   "Concurrent",
+			Value: strconv.FormatBool(s.ExecuteConcurrently),
+		},
+		{
+			Key:   "Abort on error",
+			Value: strconv.FormatBool(s.AbortOnError),
+		},
+		{
+			Key:   "Created By",
+			Value: s.CreatedBy,
+		},
+		{
+			Key:   "Created At",
+			Value: formatJobTime(s.CreatedAt),
+		},
+	}
+}
+
+// FireTime is a point in time a schedule is going to be executed at
+type FireTime struct {
+	Time time.Time `json:"time" yaml:"time"`
+}
+
+func (ft *FireTime) Headers() []string {
+	return []string{
+		"NEXT FIRE TIME",
+	}
+}
+
+func (ft *FireTime) Row() []string {
+	return []string{
+		formatJobTime(ft.Time),
+	}
+}
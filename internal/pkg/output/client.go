@@ -38,6 +38,40 @@ func (cr *ClientRenderer) renderClientsToHumanFormat(clients []*models.Client) e
 	return RenderTable(cr.Writer, &models.Client{}, rowProviders, cr.ColCountCalculator)
 }
 
+// RenderClientsWithFields renders only the given client fields, falls back to RenderClients if no fields are given
+func (cr *ClientRenderer) RenderClientsWithFields(clients []*models.Client, fields []string) error {
+	if len(fields) == 0 {
+		return cr.RenderClients(clients)
+	}
+
+	clientsWithFields := make([]*models.ClientWithFields, 0, len(clients))
+	selectedFields := make([]map[string]interface{}, 0, len(clients))
+	for _, cl := range clients {
+		clientWithFields := &models.ClientWithFields{Client: cl, Fields: fields}
+		clientsWithFields = append(clientsWithFields, clientWithFields)
+		selectedFields = append(selectedFields, clientWithFields.SelectedFields())
+	}
+
+	return RenderByFormat(
+		cr.Format,
+		cr.Writer,
+		selectedFields,
+		func() error {
+			err := RenderHeader(cr.Writer, "GetClients")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(clientsWithFields))
+			for _, cl := range clientsWithFields {
+				rowProviders = append(rowProviders, cl)
+			}
+
+			return RenderTable(cr.Writer, &models.ClientWithFields{Fields: fields}, rowProviders, cr.ColCountCalculator)
+		},
+	)
+}
+
 func (cr *ClientRenderer) RenderClient(client *models.Client, renderDetails bool) error {
 	return RenderByFormat(
 		cr.Format,
@@ -131,3 +165,19 @@ func (cr *ClientRenderer) renderTunnels(tunnels []*models.Tunnel) (err error) {
 
 	return RenderTable(cr.Writer, &models.Tunnel{}, rows, cr.ColCountCalculator)
 }
+
+// RenderDeleteResults renders the outcome of deleting clients
+func (cr *ClientRenderer) RenderDeleteResults(results []*models.ClientOperationResult) error {
+	return RenderByFormat(
+		cr.Format,
+		cr.Writer,
+		results,
+		func() error {
+			if len(results) == 0 {
+				_, err := fmt.Fprintln(cr.Writer, "No clients to delete")
+				return err
+			}
+			return renderClientOperationResults(cr.Writer, "Deleted clients", results, cr.ColCountCalculator)
+		},
+	)
+}
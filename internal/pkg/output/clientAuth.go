@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientAuthRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (car *ClientAuthRenderer) RenderClientAuths(auths []*models.ClientAuth) error {
+	return RenderByFormat(
+		car.Format,
+		car.Writer,
+		auths,
+		func() error {
+			err := RenderHeader(car.Writer, "Client Auth Credentials")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(auths))
+			for _, a := range auths {
+				rowProviders = append(rowProviders, a)
+			}
+
+			return RenderTable(car.Writer, &models.ClientAuth{}, rowProviders, car.ColCountCalculator)
+		},
+	)
+}
+
+// RenderClientAuthConfig renders new client auth credentials followed by the rport.conf snippet to use them
+func (car *ClientAuthRenderer) RenderClientAuthConfig(authConfig *models.ClientAuthConfig) error {
+	return RenderByFormat(
+		car.Format,
+		car.Writer,
+		authConfig,
+		func() error {
+			if authConfig == nil {
+				return nil
+			}
+			err := RenderHeader(car.Writer, fmt.Sprintf("Client Auth Credentials [%s]\n", authConfig.ID))
+			if err != nil {
+				return err
+			}
+			RenderKeyValues(car.Writer, authConfig)
+
+			_, err = fmt.Fprintf(car.Writer, "\nAdd the following to the rport.conf of the client:\n\n%s", authConfig.RportConf)
+			return err
+		},
+	)
+}
+
+func (car *ClientAuthRenderer) RenderDelete(s KvProvider) error {
+	return RenderByFormat(
+		car.Format,
+		car.Writer,
+		s,
+		func() error {
+			RenderKeyValues(car.Writer, s)
+			return nil
+		},
+	)
+}
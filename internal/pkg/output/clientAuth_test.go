@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderClientAuthConfig(t *testing.T) {
+	authConfig := models.NewClientAuthConfig(models.ClientAuth{ID: "web", Password: "secret"}, "https://rport.example.com")
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `Client Auth Credentials [web]
+
+KEY       VALUE                     
+ID:       web                       
+Password: secret                    
+Server:   https://rport.example.com 
+
+Add the following to the rport.conf of the client:
+
+[client]
+  server = "https://rport.example.com"
+  auth = "web:secret"
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `{"id":"web","password":"secret","server":"https://rport.example.com","rport_conf":"[client]\n  server = \"https://rport.example.com\"\n  auth = \"web:secret\"\n"}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			car := &ClientAuthRenderer{
+				ColCountCalculator: func() int {
+					return 150
+				},
+				Writer: &buf,
+				Format: tc.Format,
+			}
+
+			err := car.RenderClientAuthConfig(authConfig)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
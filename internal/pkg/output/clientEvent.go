@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+var clientEventColors = map[string]color.Attribute{
+	models.ClientEventConnected:    color.FgGreen,
+	models.ClientEventNew:          color.FgGreen,
+	models.ClientEventDisconnected: color.FgRed,
+	models.ClientEventRemoved:      color.FgRed,
+	models.ClientEventChanged:      color.FgYellow,
+}
+
+// ClientEventRenderer renders one line per event, with the json format this results in newline delimited json
+type ClientEventRenderer struct {
+	Writer io.Writer
+	Format string
+}
+
+func (cer *ClientEventRenderer) RenderClientEvent(e *models.ClientEvent) error {
+	return RenderByFormat(
+		cer.Format,
+		cer.Writer,
+		e,
+		func() error {
+			return cer.renderClientEventInHumanFormat(e)
+		},
+	)
+}
+
+func (cer *ClientEventRenderer) renderClientEventInHumanFormat(e *models.ClientEvent) error {
+	if e == nil {
+		return nil
+	}
+
+	co := color.New(clientEventColors[e.Type])
+	_, err := fmt.Fprintf(
+		cer.Writer,
+		"%s %s %s [%s]",
+		e.Time.Format(time.RFC3339),
+		co.Sprintf("%-12s", strings.ToUpper(e.Type)),
+		e.ClientName,
+		e.ClientID,
+	)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]string, 0, len(e.Changes))
+	for _, change := range e.Changes {
+		changes = append(changes, fmt.Sprintf("%s: %q -> %q", change.Field, change.From, change.To))
+	}
+	if len(changes) > 0 {
+		_, err = fmt.Fprintf(cer.Writer, " %s", strings.Join(changes, ", "))
+	} else if e.Address != "" {
+		_, err = fmt.Fprintf(cer.Writer, " address: %s", e.Address)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(cer.Writer)
+
+	return err
+}
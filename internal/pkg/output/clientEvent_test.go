@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderClientEvent(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() {
+		color.NoColor = noColor
+	}()
+
+	eventTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		Name           string
+		Format         string
+		Event          *models.ClientEvent
+		ExpectedOutput string
+	}{
+		{
+			Name:   "human with changes",
+			Format: FormatHuman,
+			Event: &models.ClientEvent{
+				Time:       eventTime,
+				Type:       models.ClientEventDisconnected,
+				ClientID:   "123",
+				ClientName: "web1",
+				Address:    "1.1.1.1",
+				Changes: []models.ClientFieldChange{
+					{Field: "connection_state", From: "connected", To: "disconnected"},
+				},
+			},
+			ExpectedOutput: "2022-01-02T03:04:05Z DISCONNECTED web1 [123] connection_state: \"connected\" -> \"disconnected\"\n",
+		},
+		{
+			Name:   "human new client",
+			Format: FormatHuman,
+			Event: &models.ClientEvent{
+				Time:       eventTime,
+				Type:       models.ClientEventNew,
+				ClientID:   "124",
+				ClientName: "web2",
+				Address:    "2.2.2.2",
+			},
+			ExpectedOutput: "2022-01-02T03:04:05Z NEW          web2 [124] address: 2.2.2.2\n",
+		},
+		{
+			Name:   "json",
+			Format: FormatJSON,
+			Event: &models.ClientEvent{
+				Time:       eventTime,
+				Type:       models.ClientEventConnected,
+				ClientID:   "124",
+				ClientName: "web2",
+				ConnState:  "connected",
+			},
+			ExpectedOutput: `{"time":"2022-01-02T03:04:05Z","type":"connected","client_id":"124","client_name":"web2","connection_state":"connected","disconnected_at":"","address":""}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			cer := &ClientEventRenderer{
+				Writer: &buf,
+				Format: tc.Format,
+			}
+
+			err := cer.RenderClientEvent(tc.Event)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientGroupRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (cgr *ClientGroupRenderer) RenderClientGroups(groups []*models.ClientGroup) error {
+	return RenderByFormat(
+		cgr.Format,
+		cgr.Writer,
+		groups,
+		func() error {
+			return cgr.renderClientGroupsToHumanFormat(groups)
+		},
+	)
+}
+
+func (cgr *ClientGroupRenderer) renderClientGroupsToHumanFormat(groups []*models.ClientGroup) error {
+	err := RenderHeader(cgr.Writer, "Client Groups")
+	if err != nil {
+		return err
+	}
+
+	rowProviders := make([]RowData, 0, len(groups))
+	for _, g := range groups {
+		rowProviders = append(rowProviders, g)
+	}
+
+	return RenderTable(cgr.Writer, &models.ClientGroup{}, rowProviders, cgr.ColCountCalculator)
+}
+
+func (cgr *ClientGroupRenderer) RenderClientGroup(group *models.ClientGroup) error {
+	return RenderByFormat(
+		cgr.Format,
+		cgr.Writer,
+		group,
+		func() error {
+			if group == nil {
+				return nil
+			}
+			err := RenderHeader(cgr.Writer, fmt.Sprintf("Client Group [%s]\n", group.ID))
+			if err != nil {
+				return err
+			}
+			RenderKeyValues(cgr.Writer, group)
+			return nil
+		},
+	)
+}
+
+func (cgr *ClientGroupRenderer) RenderDelete(s KvProvider) error {
+	return RenderByFormat(
+		cgr.Format,
+		cgr.Writer,
+		s,
+		func() error {
+			RenderKeyValues(cgr.Writer, s)
+			return nil
+		},
+	)
+}
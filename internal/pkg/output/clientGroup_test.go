@@ -0,0 +1,83 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+var clientGroupsStub = []*models.ClientGroup{
+	{
+		ID:          "debian",
+		Description: "all debian web servers",
+		Params: models.ClientGroupParams{
+			"os_family": {"debian"},
+			"name":      {"web*", "www*"},
+			"tag":       nil,
+		},
+		ClientIDs: []string{"123", "124"},
+	},
+}
+
+func TestRenderClientGroups(t *testing.T) {
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `Client Groups
+ID     DESCRIPTION            CLIENTS PARAMS                         
+debian all debian web servers 2       name=web*,www*                 
+                                      os_family=debian               
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `[{"id":"debian","description":"all debian web servers","params":{"name":["web*","www*"],"os_family":["debian"],"tag":null},"client_ids":["123","124"]}]
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			cgr := &ClientGroupRenderer{
+				ColCountCalculator: func() int {
+					return 150
+				},
+				Writer: buf,
+				Format: tc.Format,
+			}
+
+			err := cgr.RenderClientGroups(clientGroupsStub)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
+
+func TestRenderClientGroup(t *testing.T) {
+	buf := &bytes.Buffer{}
+	cgr := &ClientGroupRenderer{
+		Writer: buf,
+		Format: FormatHuman,
+	}
+
+	err := cgr.RenderClientGroup(clientGroupsStub[0])
+	assert.NoError(t, err)
+	assert.Equal(t, `Client Group [debian]
+
+KEY          VALUE                  
+ID:          debian                 
+Description: all debian web servers 
+Params:      name=web*,www*         
+             os_family=debian       
+Client IDs:  123                    
+             124                    
+`, buf.String())
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const sparklineWidth = 40
+
+type metricSummary struct {
+	name   string
+	values []float64
+}
+
+func (ms *metricSummary) Headers() []string {
+	return []string{
+		"METRIC",
+		"SPARKLINE",
+		"MIN",
+		"AVG",
+		"MAX",
+	}
+}
+
+func (ms *metricSummary) Row() []string {
+	minValue, avgValue, maxValue := MinAvgMax(ms.values)
+	return []string{
+		ms.name,
+		Sparkline(ms.values, sparklineWidth),
+		formatPercent(minValue),
+		formatPercent(avgValue),
+		formatPercent(maxValue),
+	}
+}
+
+func formatPercent(value float64) string {
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+type ClientMetricsRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (cmr *ClientMetricsRenderer) RenderClientMetrics(report *models.ClientMetricsReport) error {
+	return RenderByFormat(
+		cmr.Format,
+		cmr.Writer,
+		report,
+		func() error {
+			return cmr.renderClientMetricsInHumanFormat(report)
+		},
+	)
+}
+
+func (cmr *ClientMetricsRenderer) renderClientMetricsInHumanFormat(report *models.ClientMetricsReport) error {
+	if report == nil {
+		return nil
+	}
+
+	err := RenderHeader(cmr.Writer, fmt.Sprintf("Client metrics [%s] %s", report.ClientID, report.ClientName))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(
+		cmr.Writer,
+		"%s - %s, %d measurements\n\n",
+		report.Since.Format(time.RFC3339),
+		report.Until.Format(time.RFC3339),
+		len(report.Metrics),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Metrics) == 0 {
+		_, err = fmt.Fprintln(cmr.Writer, "No metrics measured in the given time range")
+		return err
+	}
+
+	cpu := &metricSummary{name: "CPU"}
+	memory := &metricSummary{name: "MEMORY"}
+	ioUsage := &metricSummary{name: "IO"}
+	for _, m := range report.Metrics {
+		cpu.values = append(cpu.values, m.CPUUsagePercent)
+		memory.values = append(memory.values, m.MemoryUsagePercent)
+		ioUsage.values = append(ioUsage.values, m.IOUsagePercent)
+	}
+
+	return RenderTable(cmr.Writer, &metricSummary{}, []RowData{cpu, memory, ioUsage}, cmr.ColCountCalculator)
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderClientMetrics(t *testing.T) {
+	since := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	report := &models.ClientMetricsReport{
+		ClientID:   "123",
+		ClientName: "web1",
+		Since:      since,
+		Until:      since.Add(time.Hour),
+		Metrics: []*models.ClientMetrics{
+			{Timestamp: since, CPUUsagePercent: 10, MemoryUsagePercent: 50, IOUsagePercent: 1},
+			{Timestamp: since.Add(time.Minute), CPUUsagePercent: 90, MemoryUsagePercent: 50, IOUsagePercent: 2},
+			{Timestamp: since.Add(2 * time.Minute), CPUUsagePercent: 20, MemoryUsagePercent: 53, IOUsagePercent: 3},
+		},
+	}
+
+	testCases := []struct {
+		Format         string
+		Report         *models.ClientMetricsReport
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			Report: report,
+			ExpectedOutput: `Client metrics [123] web1
+2022-03-01T10:00:00Z - 2022-03-01T11:00:00Z, 3 measurements
+
+METRIC SPARKLINE MIN   AVG   MAX   
+CPU    ▁█▂       10.0% 40.0% 90.0% 
+MEMORY ▁▁█       50.0% 51.0% 53.0% 
+IO     ▁▅█       1.0%  2.0%  3.0%  
+`,
+		},
+		{
+			Format: FormatHuman,
+			Report: &models.ClientMetricsReport{ClientID: "123", Since: since, Until: since.Add(time.Hour)},
+			ExpectedOutput: `Client metrics [123] 
+2022-03-01T10:00:00Z - 2022-03-01T11:00:00Z, 0 measurements
+
+No metrics measured in the given time range
+`,
+		},
+		{
+			Format: FormatJSON,
+			Report: &models.ClientMetricsReport{
+				ClientID: "123",
+				Since:    since,
+				Until:    since.Add(time.Hour),
+				Metrics:  report.Metrics[:1],
+			},
+			ExpectedOutput: `{"client_id":"123","since":"2022-03-01T10:00:00Z","until":"2022-03-01T11:00:00Z","metrics":[{"timestamp":"2022-03-01T10:00:00Z","cpu_usage_percent":10,"memory_usage_percent":50,"io_usage_percent":1}]}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			cmr := &ClientMetricsRenderer{
+				ColCountCalculator: func() int {
+					return 150
+				},
+				Writer: &buf,
+				Format: tc.Format,
+			}
+
+			err := cmr.RenderClientMetrics(tc.Report)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
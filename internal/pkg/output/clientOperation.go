@@ -0,0 +1,26 @@
+package output
+
+import (
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func renderClientOperationResults(
+	w io.Writer,
+	header string,
+	results []*models.ClientOperationResult,
+	colCountCalculator CalcTerminalColumnsCount,
+) error {
+	err := RenderHeader(w, header)
+	if err != nil {
+		return err
+	}
+
+	rowProviders := make([]RowData, 0, len(results))
+	for _, r := range results {
+		rowProviders = append(rowProviders, r)
+	}
+
+	return RenderTable(w, &models.ClientOperationResult{}, rowProviders, colCountCalculator)
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type clientStatsRow struct {
+	by    []string
+	group *models.ClientStatsGroup
+}
+
+func (csr *clientStatsRow) Headers() []string {
+	headers := make([]string, 0, len(csr.by)+2)
+	for _, field := range csr.by {
+		headers = append(headers, strings.ToUpper(field))
+	}
+
+	return append(headers, "COUNT", "PERCENT")
+}
+
+func (csr *clientStatsRow) Row() []string {
+	row := make([]string, 0, len(csr.by)+2)
+	for _, field := range csr.by {
+		row = append(row, csr.group.Fields[field])
+	}
+
+	return append(row, strconv.Itoa(csr.group.Count), fmt.Sprintf("%.1f%%", csr.group.Percent))
+}
+
+func (cr *ClientRenderer) RenderClientStats(stats *models.ClientStats) error {
+	return RenderByFormat(
+		cr.Format,
+		cr.Writer,
+		stats,
+		func() error {
+			return cr.renderClientStatsToHumanFormat(stats)
+		},
+	)
+}
+
+func (cr *ClientRenderer) renderClientStatsToHumanFormat(stats *models.ClientStats) error {
+	if stats == nil {
+		return nil
+	}
+
+	err := RenderHeader(cr.Writer, "Client statistics")
+	if err != nil {
+		return err
+	}
+
+	rowProviders := make([]RowData, 0, len(stats.Groups))
+	for _, group := range stats.Groups {
+		rowProviders = append(rowProviders, &clientStatsRow{by: stats.By, group: group})
+	}
+
+	err = RenderTable(cr.Writer, &clientStatsRow{by: stats.By}, rowProviders, cr.ColCountCalculator)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cr.Writer, "Total: %d clients\n", stats.Total)
+
+	return err
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderClientStats(t *testing.T) {
+	stats := models.NewClientStats([]*models.Client{
+		{ID: "1", OsFamily: "debian", ConnState: "connected"},
+		{ID: "2", OsFamily: "windows", ConnState: "connected"},
+		{ID: "3", OsFamily: "debian", ConnState: "connected"},
+	}, []string{"os_family", "connection_state"})
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `Client statistics
+OS FAMILY CONNECTION STATE COUNT PERCENT 
+debian    connected        2     66.7%   
+windows   connected        1     33.3%   
+Total: 3 clients
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `{"by":["os_family","connection_state"],"total":3,"groups":[{"fields":{"connection_state":"connected","os_family":"debian"},"count":2,"percent":66.66666666666667},{"fields":{"connection_state":"connected","os_family":"windows"},"count":1,"percent":33.333333333333336}]}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			cr := &ClientRenderer{
+				ColCountCalculator: func() int {
+					return 150
+				},
+				Writer: &buf,
+				Format: tc.Format,
+			}
+
+			err := cr.RenderClientStats(stats)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ClientUpdatesRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (cur *ClientUpdatesRenderer) RenderClientsUpdates(updates []*models.ClientUpdates) error {
+	return RenderByFormat(
+		cur.Format,
+		cur.Writer,
+		updates,
+		func() error {
+			err := RenderHeader(cur.Writer, "Client updates")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(updates))
+			for _, cu := range updates {
+				rowProviders = append(rowProviders, cu)
+			}
+
+			return RenderTable(cur.Writer, &models.ClientUpdates{}, rowProviders, cur.ColCountCalculator)
+		},
+	)
+}
+
+// RenderUpdateSummaries renders the update status of a client with every pending update
+func (cur *ClientUpdatesRenderer) RenderUpdateSummaries(client *models.Client) error {
+	if client == nil {
+		return nil
+	}
+
+	return RenderByFormat(
+		cur.Format,
+		cur.Writer,
+		client.UpdatesStatus,
+		func() error {
+			err := RenderHeader(cur.Writer, fmt.Sprintf("Client [%s] %s\n", client.ID, client.Name))
+			if err != nil {
+				return err
+			}
+
+			if client.UpdatesStatus == nil {
+				_, err = fmt.Fprintln(cur.Writer, "The client has not reported its update status yet")
+				return err
+			}
+
+			cr := &ClientRenderer{
+				ColCountCalculator: cur.ColCountCalculator,
+				Writer:             cur.Writer,
+				Format:             cur.Format,
+			}
+			return cr.renderUpdatesStatus(client.UpdatesStatus)
+		},
+	)
+}
+
+func (cur *ClientUpdatesRenderer) RenderUpdatesRefresh(results []*models.ClientOperationResult) error {
+	return RenderByFormat(
+		cur.Format,
+		cur.Writer,
+		results,
+		func() error {
+			return renderClientOperationResults(cur.Writer, "Update status refresh", results, cur.ColCountCalculator)
+		},
+	)
+}
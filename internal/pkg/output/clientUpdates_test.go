@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderClientsUpdates(t *testing.T) {
+	refreshed := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	updates := []*models.ClientUpdates{
+		{
+			ClientID:                 "123",
+			ClientName:               "web1",
+			UpdatesAvailable:         2,
+			SecurityUpdatesAvailable: 1,
+			RebootPending:            true,
+			Refreshed:                &refreshed,
+		},
+		{
+			ClientID:   "124",
+			ClientName: "web2",
+		},
+	}
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `Client updates
+ID  NAME UPDATES SECURITY UPDATES REBOOT PENDING REFRESHED            ERROR 
+123 web1 2       1                true           2022-01-02T03:04:05Z       
+124 web2 0       0                false                                     
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `[{"client_id":"123","client_name":"web1","updates_available":2,"security_updates_available":1,"reboot_pending":true,"refreshed":"2022-01-02T03:04:05Z"},{"client_id":"124","client_name":"web2","updates_available":0,"security_updates_available":0,"reboot_pending":false}]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			cur := &ClientUpdatesRenderer{
+				ColCountCalculator: func() int {
+					return 150
+				},
+				Writer: &buf,
+				Format: tc.Format,
+			}
+
+			err := cur.RenderClientsUpdates(updates)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
+
+func TestRenderUpdateSummariesWithoutStatus(t *testing.T) {
+	buf := bytes.Buffer{}
+	cur := &ClientUpdatesRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := cur.RenderUpdateSummaries(&models.Client{ID: "123", Name: "web1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Client [123] web1\n\nThe client has not reported its update status yet\n", buf.String())
+}
@@ -339,3 +339,51 @@ updatesstatus: null
 		})
 	}
 }
+
+func TestRenderClientsWithFields(t *testing.T) {
+	clients := []*models.Client{
+		{
+			ID:           "123",
+			CPUModelName: "Intel Xeon",
+			MemoryTotal:  2000000000,
+			Ipv4:         []string{"10.0.0.1", "10.0.0.2"},
+		},
+	}
+	fields := []string{"id", "cpu_model_name", "mem_total", "ipv4"}
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `GetClients
+ID  CPU MODEL NAME MEM TOTAL IPV4               
+123 Intel Xeon     2.0 GB    10.0.0.1, 10.0.0.2 
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `[{"cpu_model_name":"Intel Xeon","id":"123","ipv4":["10.0.0.1","10.0.0.2"],"mem_total":2000000000}]
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			cr := &ClientRenderer{
+				ColCountCalculator: func() int {
+					return 150
+				},
+				Writer: buf,
+				Format: tc.Format,
+			}
+
+			err := cr.RenderClientsWithFields(clients, fields)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+// statusNotExecuted is shown for a client which is missing in one of the compared execution logs
+const statusNotExecuted = "not executed"
+
+type ExecLogRenderer struct {
+	Writer io.Writer
+	Format string
+}
+
+// RenderExecLogHeader renders who executed the jobs of an execution log, when and against which server
+func (elr *ExecLogRenderer) RenderExecLogHeader(h *models.ExecLogHeader) error {
+	return RenderByFormat(
+		elr.Format,
+		elr.Writer,
+		h,
+		func() error {
+			err := RenderHeader(elr.Writer, "Execution log")
+			if err != nil {
+				return err
+			}
+
+			RenderKeyValues(elr.Writer, h)
+			return nil
+		},
+	)
+}
+
+// RenderExecLogDiffs renders the status change of each client followed by the unified diffs of its output
+func (elr *ExecLogRenderer) RenderExecLogDiffs(diffs []*models.ExecLogDiff) error {
+	return RenderByFormat(
+		elr.Format,
+		elr.Writer,
+		diffs,
+		func() error {
+			return elr.renderExecLogDiffsInHumanFormat(diffs)
+		},
+	)
+}
+
+func (elr *ExecLogRenderer) renderExecLogDiffsInHumanFormat(diffs []*models.ExecLogDiff) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(elr.Writer, "no differences")
+		return err
+	}
+
+	for _, d := range diffs {
+		header := d.ClientID
+		if d.ClientName != "" {
+			header = fmt.Sprintf("%s (%s)", d.ClientName, d.ClientID)
+		}
+		if d.StatusBefore != d.StatusAfter {
+			header += fmt.Sprintf(": %s -> %s", formatDiffStatus(d.StatusBefore), formatDiffStatus(d.StatusAfter))
+		}
+
+		_, err := fmt.Fprintf(elr.Writer, "%s\n%s%s", header, d.StdoutDiff, d.StderrDiff)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatDiffStatus(status string) string {
+	if status == "" {
+		return statusNotExecuted
+	}
+
+	return status
+}
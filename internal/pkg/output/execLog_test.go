@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderExecLogDiffs(t *testing.T) {
+	diffs := []*models.ExecLogDiff{
+		{
+			ClientID:    "cl5",
+			StatusAfter: models.JobStatusSuccessful,
+		},
+		{
+			ClientID:     "cl3",
+			ClientName:   "db",
+			StatusBefore: models.JobStatusSuccessful,
+			StatusAfter:  models.JobStatusSuccessful,
+			StdoutDiff:   "--- before.yaml stdout\n+++ after.yaml stdout\n@@ -1 +1 @@\n-b\n+B\n",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	elr := &ExecLogRenderer{Writer: buf, Format: FormatHuman}
+	err := elr.RenderExecLogDiffs(diffs)
+	require.NoError(t, err)
+
+	expected := `cl5: not executed -> successful
+db (cl3)
+--- before.yaml stdout
++++ after.yaml stdout
+@@ -1 +1 @@
+-b
++B
+`
+	assert.Equal(t, expected, buf.String())
+}
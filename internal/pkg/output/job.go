@@ -3,6 +3,7 @@ package output
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
@@ -10,13 +11,23 @@ import (
 )
 
 type JobRenderer struct {
-	Writer           io.Writer
-	Format           string
-	IsFullOutput     bool
+	Writer       io.Writer
+	Format       string
+	IsFullOutput bool
+	// Aggregate buffers the finished jobs until Flush renders the clients with identical output as one group
+	Aggregate        bool
 	gotPartialResult bool
+	bufferedJobs     []*models.Job
 }
 
 func (jr *JobRenderer) RenderJob(j *models.Job) error {
+	if jr.Aggregate {
+		if !j.FinishedAt.IsZero() {
+			jr.bufferedJobs = append(jr.bufferedJobs, j)
+		}
+		return nil
+	}
+
 	if jr.shouldRender(j) {
 		return RenderByFormat(
 			jr.Format,
@@ -30,6 +41,135 @@ func (jr *JobRenderer) RenderJob(j *models.Job) error {
 	return nil
 }
 
+// RenderJobStarted renders only the job id in the human format to make it easy to capture in shell scripts
+func (jr *JobRenderer) RenderJobStarted(js *models.JobStarted) error {
+	return RenderByFormat(
+		jr.Format,
+		jr.Writer,
+		js,
+		func() error {
+			_, err := fmt.Fprintln(jr.Writer, js.Jid)
+			return err
+		},
+	)
+}
+
+// summaryColors mark the statuses in the summary, a count of 0 isn't marked
+var summaryColors = map[string]color.Attribute{
+	models.SummarySucceeded:   color.FgGreen,
+	models.SummaryFailed:      color.FgRed,
+	models.SummaryTimedOut:    color.FgYellow,
+	models.SummaryNotReported: color.FgRed,
+}
+
+// RenderSummary renders the counts and durations of the jobs at the end of a multi-client execution
+func (jr *JobRenderer) RenderSummary(es *models.ExecutionSummary) error {
+	return RenderByFormat(
+		jr.Format,
+		jr.Writer,
+		es,
+		func() error {
+			err := RenderHeader(jr.Writer, "Summary")
+			if err != nil {
+				return err
+			}
+
+			RenderKeyValues(jr.Writer, markedSummary(es))
+			return nil
+		},
+	)
+}
+
+func markedSummary(es *models.ExecutionSummary) keyValues {
+	kvs := es.KeyValues()
+	for i, kv := range kvs {
+		if attr, ok := summaryColors[kv.Key]; ok && !strings.HasPrefix(kv.Value, "0") {
+			kvs[i].Value = color.New(attr).Sprint(kv.Value)
+		}
+	}
+
+	return kvs
+}
+
+// RenderCommandVariants renders the clients and the command or script they would execute, as shown by --dry-run
+func (jr *JobRenderer) RenderCommandVariants(variants []*models.CommandVariant) error {
+	return RenderByFormat(
+		jr.Format,
+		jr.Writer,
+		variants,
+		func() error {
+			for _, v := range variants {
+				payload := v.Command
+				if v.Script != "" {
+					payload = v.Script
+				}
+				_, err := fmt.Fprintf(
+					jr.Writer,
+					"%s: %s\n%s\n",
+					formatClientsCount(len(v.Clients)),
+					strings.Join(v.Clients, ", "),
+					jr.genShiftedMultilineStr(payload, "    "),
+				)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// Flush renders the jobs buffered in the aggregate mode, the clients with the same status, output and error are
+// rendered once as a group, the biggest groups first
+func (jr *JobRenderer) Flush() error {
+	if len(jr.bufferedJobs) == 0 {
+		return nil
+	}
+
+	groups := groupJobs(jr.bufferedJobs)
+	jr.bufferedJobs = nil
+
+	return RenderByFormat(
+		jr.Format,
+		jr.Writer,
+		groups,
+		func() error {
+			return jr.renderJobGroupsInHumanFormat(groups)
+		},
+	)
+}
+
+func groupJobs(jobs []*models.Job) []*models.JobGroup {
+	type groupKey struct {
+		status, err, stdout, stderr string
+	}
+
+	groups := make([]*models.JobGroup, 0)
+	groupsByKey := make(map[groupKey]*models.JobGroup)
+	for _, j := range jobs {
+		key := groupKey{status: j.Status, err: j.Error, stdout: j.Result.Stdout, stderr: j.Result.Stderr}
+		g, ok := groupsByKey[key]
+		if !ok {
+			g = &models.JobGroup{Status: j.Status, Error: j.Error, Result: j.Result}
+			groupsByKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Clients = append(g.Clients, extractClientNameOrID(j))
+	}
+
+	for _, g := range groups {
+		sort.Strings(g.Clients)
+	}
+	sort.SliceStable(groups, func(i, k int) bool {
+		if len(groups[i].Clients) != len(groups[k].Clients) {
+			return len(groups[i].Clients) > len(groups[k].Clients)
+		}
+		return groups[i].Clients[0] < groups[k].Clients[0]
+	})
+
+	return groups
+}
+
 func (jr *JobRenderer) shouldRender(j *models.Job) bool {
 	partialResult := j.FinishedAt.IsZero()
 	if partialResult {
@@ -64,17 +204,14 @@ func (jr *JobRenderer) genShiftedMultilineStr(input, shiftStr string) string {
 	return strings.Join(inputLines, "\n")
 }
 
-func (jr *JobRenderer) formatError(j *models.Job, shiftStr string) string {
-	errOutput := ""
-	if j.Error != "" {
-		errOutput = j.Error
-	}
-	if j.Result.Stderr != "" {
+func (jr *JobRenderer) formatError(jobErr, stderr, shiftStr string) string {
+	errOutput := jobErr
+	if stderr != "" {
 		sep := ""
 		if errOutput != "" {
 			sep = " "
 		}
-		errOutput += sep + j.Result.Stderr
+		errOutput += sep + stderr
 	}
 
 	errOutput = strings.Trim(errOutput, "\n")
@@ -94,7 +231,7 @@ func (jr *JobRenderer) formatError(j *models.Job, shiftStr string) string {
 	return strings.Join(inputLines, "\n")
 }
 
-func (jr *JobRenderer) extractClientNameOrID(j *models.Job) string {
+func extractClientNameOrID(j *models.Job) string {
 	if j.ClientName != "" {
 		return j.ClientName
 	}
@@ -110,28 +247,7 @@ func (jr *JobRenderer) renderJobInHumanFormat(j *models.Job) error {
 	var outputs []string
 
 	if !jr.IsFullOutput {
-		_, err := fmt.Fprintln(jr.Writer, jr.extractClientNameOrID(j))
-		if err != nil {
-			return err
-		}
-
-		stdOut := jr.genShiftedMultilineStr(j.Result.Stdout, "    ")
-		if stdOut != "" {
-			co := color.New(color.FgGreen)
-			_, err = co.Fprintln(jr.Writer, stdOut)
-			if err != nil {
-				return err
-			}
-		}
-		stdErr := jr.formatError(j, "    ")
-		if stdErr != "" {
-			co := color.New(color.FgRed)
-			_, err = co.Fprintln(jr.Writer, stdErr)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+		return jr.renderOutput(extractClientNameOrID(j), j.Error, j.Result)
 	}
 
 	outputs = []string{
@@ -149,7 +265,7 @@ func (jr *JobRenderer) renderJobInHumanFormat(j *models.Job) error {
 	}
 
 	outputs = append(outputs, "    Command Error Output:")
-	errOut := jr.formatError(j, "      ")
+	errOut := jr.formatError(j.Error, j.Result.Stderr, "      ")
 	if errOut != "" {
 		outputs = append(outputs, errOut)
 	}
@@ -180,3 +296,50 @@ func (jr *JobRenderer) renderJobInHumanFormat(j *models.Job) error {
 
 	return nil
 }
+
+func (jr *JobRenderer) renderJobGroupsInHumanFormat(groups []*models.JobGroup) error {
+	for _, g := range groups {
+		header := fmt.Sprintf("%s (%s): %s", formatClientsCount(len(g.Clients)), g.Status, strings.Join(g.Clients, ", "))
+
+		err := jr.renderOutput(header, g.Error, g.Result)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatClientsCount(count int) string {
+	if count == 1 {
+		return "1 client"
+	}
+	return fmt.Sprintf("%d clients", count)
+}
+
+// renderOutput renders the header followed by the shifted stdout in green and the error and stderr in red
+func (jr *JobRenderer) renderOutput(header, jobErr string, result models.JobResult) error {
+	_, err := fmt.Fprintln(jr.Writer, header)
+	if err != nil {
+		return err
+	}
+
+	stdOut := jr.genShiftedMultilineStr(result.Stdout, "    ")
+	if stdOut != "" {
+		co := color.New(color.FgGreen)
+		_, err = co.Fprintln(jr.Writer, stdOut)
+		if err != nil {
+			return err
+		}
+	}
+	stdErr := jr.formatError(jobErr, result.Stderr, "    ")
+	if stdErr != "" {
+		co := color.New(color.FgRed)
+		_, err = co.Fprintln(jr.Writer, stdErr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
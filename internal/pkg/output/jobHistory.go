@@ -0,0 +1,55 @@
+package output
+
+import (
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type JobHistoryRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (jhr *JobHistoryRenderer) RenderJobs(jobs []*models.Job) error {
+	return RenderByFormat(
+		jhr.Format,
+		jhr.Writer,
+		jobs,
+		func() error {
+			err := RenderHeader(jhr.Writer, "Jobs")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(jobs))
+			for _, j := range jobs {
+				rowProviders = append(rowProviders, j)
+			}
+
+			return RenderTable(jhr.Writer, &models.Job{}, rowProviders, jhr.ColCountCalculator)
+		},
+	)
+}
+
+func (jhr *JobHistoryRenderer) RenderMultiJobs(multiJobs []*models.MultiJob) error {
+	return RenderByFormat(
+		jhr.Format,
+		jhr.Writer,
+		multiJobs,
+		func() error {
+			err := RenderHeader(jhr.Writer, "Multi-client jobs")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(multiJobs))
+			for _, mj := range multiJobs {
+				rowProviders = append(rowProviders, mj)
+			}
+
+			return RenderTable(jhr.Writer, &models.MultiJob{}, rowProviders, jhr.ColCountCalculator)
+		},
+	)
+}
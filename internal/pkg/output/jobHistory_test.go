@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderJobs(t *testing.T) {
+	startedAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	jobs := []*models.Job{
+		{
+			Jid:        "j1",
+			Status:     "successful",
+			ClientID:   "123",
+			ClientName: "web1",
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(time.Second),
+			CreatedBy:  "admin",
+			Command:    "uptime",
+		},
+		{
+			Jid:       "j2",
+			Status:    "running",
+			ClientID:  "123",
+			StartedAt: startedAt,
+			CreatedBy: "admin",
+			Command:   "sleep 60",
+		},
+	}
+
+	buf := bytes.Buffer{}
+	jhr := &JobHistoryRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := jhr.RenderJobs(jobs)
+	require.NoError(t, err)
+	assert.Equal(t, `Jobs
+JOB ID STATUS     CLIENT STARTED AT           FINISHED AT          CREATED BY COMMAND  
+j1     successful web1   2022-03-01T12:00:00Z 2022-03-01T12:00:01Z admin      uptime   
+j2     running    123    2022-03-01T12:00:00Z                      admin      sleep 60 
+`, buf.String())
+}
+
+func TestRenderMultiJobs(t *testing.T) {
+	multiJobs := []*models.MultiJob{
+		{
+			Jid:       "j1",
+			StartedAt: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+			CreatedBy: "admin",
+			ClientIDs: []string{"1", "2"},
+			Command:   "uptime",
+		},
+	}
+
+	buf := bytes.Buffer{}
+	jhr := &JobHistoryRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := jhr.RenderMultiJobs(multiJobs)
+	require.NoError(t, err)
+	assert.Equal(t, `Multi-client jobs
+JOB ID STARTED AT           CREATED BY CLIENTS COMMAND 
+j1     2022-03-01T12:00:00Z admin      2       uptime  
+`, buf.String())
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -320,3 +321,211 @@ interpreter: cmd
 		})
 	}
 }
+
+func TestRenderJobStarted(t *testing.T) {
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format:         FormatHuman,
+			ExpectedOutput: "multi-1\n",
+		},
+		{
+			Format:         FormatJSON,
+			ExpectedOutput: "{\"jid\":\"multi-1\"}\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			jr := &JobRenderer{
+				Writer: buf,
+				Format: tc.Format,
+			}
+
+			err := jr.RenderJobStarted(&models.JobStarted{Jid: "multi-1"})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
+
+func TestRenderAggregatedJobs(t *testing.T) {
+	finishedAt := time.Date(2021, 1, 1, 0, 0, 1, 0, time.UTC)
+	jobs := []*models.Job{
+		{Jid: "1", ClientName: "web2", Status: "successful", FinishedAt: finishedAt, Result: models.JobResult{Stdout: "Ubuntu 22.04\n"}},
+		{Jid: "2", ClientName: "db1", Status: "failed", FinishedAt: finishedAt, Result: models.JobResult{Stderr: "no such file"}},
+		{Jid: "3", ClientID: "id-web1", Status: "successful", FinishedAt: finishedAt, Result: models.JobResult{Stdout: "Ubuntu 22.04\n"}},
+		{Jid: "4", ClientName: "web3", Status: "successful", Result: models.JobResult{Stdout: "partial"}},
+		{Jid: "4", ClientName: "web3", Status: "successful", FinishedAt: finishedAt, Result: models.JobResult{Stdout: "Debian 11"}},
+	}
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `2 clients (successful): id-web1, web2
+    Ubuntu 22.04
+1 client (failed): db1
+    no such file
+1 client (successful): web3
+    Debian 11
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `[{"clients":["id-web1","web2"],"status":"successful","error":"","result":{"stdout":"Ubuntu 22.04\n","stderr":""}},` +
+				`{"clients":["db1"],"status":"failed","error":"","result":{"stdout":"","stderr":"no such file"}},` +
+				`{"clients":["web3"],"status":"successful","error":"","result":{"stdout":"Debian 11","stderr":""}}]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			jr := &JobRenderer{
+				Writer:    buf,
+				Format:    tc.Format,
+				Aggregate: true,
+			}
+
+			for _, j := range jobs {
+				assert.NoError(t, jr.RenderJob(j))
+			}
+			assert.Empty(t, buf.String())
+
+			assert.NoError(t, jr.Flush())
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+
+			// the buffered jobs are rendered only once
+			assert.NoError(t, jr.Flush())
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
+
+func TestRenderSummary(t *testing.T) {
+	es := &models.ExecutionSummary{
+		Targeted:       4,
+		Finished:       3,
+		Succeeded:      1,
+		Failed:         1,
+		TimedOut:       1,
+		NotReported:    []*models.SummaryClient{{ID: "cl4", Name: "db1"}},
+		MinDurationSec: 0.5,
+		AvgDurationSec: 1.25,
+		MaxDurationSec: 2,
+	}
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `Summary
+KEY                   VALUE              
+Targeted:             4                  
+Finished:             3                  
+Succeeded:            1                  
+Failed:               1                  
+Timed out:            1                  
+Not reported back:    1: db1 (cl4)       
+Duration min/avg/max: 500ms / 1.25s / 2s 
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `{"targeted":4,"finished":3,"succeeded":1,"failed":1,"timed_out":1,"not_reported":[{"id":"cl4","name":"db1"}],` +
+				`"min_duration_sec":0.5,"avg_duration_sec":1.25,"max_duration_sec":2}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			jr := &JobRenderer{
+				Writer: buf,
+				Format: tc.Format,
+			}
+
+			err := jr.RenderSummary(es)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
+
+func TestMarkedSummary(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() {
+		color.NoColor = noColor
+	}()
+
+	es := &models.ExecutionSummary{
+		Targeted:    3,
+		Finished:    2,
+		Succeeded:   1,
+		Failed:      1,
+		NotReported: []*models.SummaryClient{{ID: "cl3", Name: "db1"}},
+	}
+
+	values := map[string]string{}
+	for _, kv := range markedSummary(es) {
+		values[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "3", values["Targeted"])
+	assert.Equal(t, color.New(color.FgGreen).Sprint("1"), values[models.SummarySucceeded])
+	assert.Equal(t, color.New(color.FgRed).Sprint("1"), values[models.SummaryFailed])
+	assert.Equal(t, "0", values[models.SummaryTimedOut])
+	assert.Equal(t, color.New(color.FgRed).Sprint("1: db1 (cl3)"), values[models.SummaryNotReported])
+}
+
+func TestRenderCommandVariants(t *testing.T) {
+	variants := []*models.CommandVariant{
+		{ClientIDs: []string{"cl1", "cl2"}, Clients: []string{"web1", "web2"}, Script: "apt-get update\napt-get -y upgrade"},
+		{ClientIDs: []string{"cl3"}, Clients: []string{"db1"}, Script: "yum -y update"},
+	}
+
+	testCases := []struct {
+		Format         string
+		ExpectedOutput string
+	}{
+		{
+			Format: FormatHuman,
+			ExpectedOutput: `2 clients: web1, web2
+    apt-get update
+    apt-get -y upgrade
+1 client: db1
+    yum -y update
+`,
+		},
+		{
+			Format: FormatJSON,
+			ExpectedOutput: `[{"client_ids":["cl1","cl2"],"clients":["web1","web2"],"script":"apt-get update\napt-get -y upgrade"},` +
+				`{"client_ids":["cl3"],"clients":["db1"],"script":"yum -y update"}]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			jr := &JobRenderer{
+				Writer: buf,
+				Format: tc.Format,
+			}
+
+			err := jr.RenderCommandVariants(variants)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedOutput, buf.String())
+		})
+	}
+}
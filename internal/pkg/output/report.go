@@ -0,0 +1,208 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+const reportName = "rportcli"
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	ID        string           `xml:"id,attr,omitempty"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Timestamp string           `xml:"timestamp,attr,omitempty"`
+	Time      string           `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type tapDiagnostic struct {
+	ClientID    string  `yaml:"client_id"`
+	Jid         string  `yaml:"jid"`
+	Status      string  `yaml:"status"`
+	DurationSec float64 `yaml:"duration_sec"`
+	Error       string  `yaml:"error,omitempty"`
+	Stdout      string  `yaml:"stdout,omitempty"`
+	Stderr      string  `yaml:"stderr,omitempty"`
+}
+
+// RenderJUnitReport renders the jobs as one JUnit XML test suite with a test case per client, failed jobs are
+// failures, timed out jobs are errors and unfinished jobs are skipped
+func RenderJUnitReport(w io.Writer, executedAt time.Time, jobs []*models.Job) error {
+	suite := &junitTestSuite{
+		Name:      reportSuiteName(jobs),
+		Tests:     len(jobs),
+		TestCases: make([]*junitTestCase, 0, len(jobs)),
+	}
+	if !executedAt.IsZero() {
+		suite.Timestamp = executedAt.Format(time.RFC3339)
+	}
+
+	totalSec := 0.0
+	for _, j := range jobs {
+		durationSec := jobDurationSec(j)
+		totalSec += durationSec
+		tc := &junitTestCase{
+			Name:      extractClientNameOrID(j),
+			ClassName: j.ClientID,
+			Time:      formatReportSeconds(durationSec),
+			SystemOut: j.Result.Stdout,
+			SystemErr: j.Result.Stderr,
+		}
+		if suite.ID == "" {
+			suite.ID = j.MultiJobID
+		}
+
+		switch j.Status {
+		case models.JobStatusSuccessful:
+			// passed
+		case models.JobStatusFailed:
+			suite.Failures++
+			tc.Failure = &junitProblem{Message: "job failed", Type: j.Status, Text: jobErrorText(j)}
+		case models.JobStatusUnknown:
+			suite.Errors++
+			tc.Error = &junitProblem{Message: "job timed out", Type: j.Status, Text: jobErrorText(j)}
+		default:
+			suite.Skipped++
+			tc.Skipped = &junitProblem{Message: fmt.Sprintf("job has not finished, status %q", j.Status)}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Time = formatReportSeconds(totalSec)
+
+	suites := &junitTestSuites{
+		Name:     reportName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []*junitTestSuite{suite},
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	xmlEncoder := xml.NewEncoder(w)
+	xmlEncoder.Indent("", "  ")
+	err = xmlEncoder.Encode(suites)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// RenderTAPReport renders the jobs as TAP version 13 with a test point per client, only successful jobs are ok,
+// the status, output and error of the job are added as a YAML diagnostic block
+func RenderTAPReport(w io.Writer, jobs []*models.Job) error {
+	_, err := fmt.Fprintf(w, "TAP version 13\n1..%d\n", len(jobs))
+	if err != nil {
+		return err
+	}
+
+	for i, j := range jobs {
+		result := "ok"
+		if j.Status != models.JobStatusSuccessful {
+			result = "not ok"
+		}
+		// # starts a directive in a TAP description
+		description := strings.ReplaceAll(extractClientNameOrID(j), "#", `\#`)
+		_, err = fmt.Fprintf(w, "%s %d - %s\n", result, i+1, description)
+		if err != nil {
+			return err
+		}
+
+		diagnostic, marshalErr := yaml.Marshal(&tapDiagnostic{
+			ClientID:    j.ClientID,
+			Jid:         j.Jid,
+			Status:      j.Status,
+			DurationSec: jobDurationSec(j),
+			Error:       j.Error,
+			Stdout:      j.Result.Stdout,
+			Stderr:      j.Result.Stderr,
+		})
+		if marshalErr != nil {
+			return marshalErr
+		}
+
+		lines := strings.Split(strings.TrimRight(string(diagnostic), "\n"), "\n")
+		_, err = fmt.Fprintf(w, "  ---\n  %s\n  ...\n", strings.Join(lines, "\n  "))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reportSuiteName names the test suite after the executed command
+func reportSuiteName(jobs []*models.Job) string {
+	if len(jobs) == 0 {
+		return reportName
+	}
+	if jobs[0].IsScript {
+		return "script"
+	}
+
+	return jobs[0].Command
+}
+
+func jobErrorText(j *models.Job) string {
+	if j.Error != "" {
+		return j.Error
+	}
+
+	return j.Result.Stderr
+}
+
+func jobDurationSec(j *models.Job) float64 {
+	if j.StartedAt.IsZero() || j.FinishedAt.IsZero() {
+		return 0
+	}
+
+	return j.FinishedAt.Sub(j.StartedAt).Seconds()
+}
+
+func formatReportSeconds(sec float64) string {
+	return fmt.Sprintf("%.3f", sec)
+}
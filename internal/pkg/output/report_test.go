@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportTestJobs() []*models.Job {
+	startedAt := time.Date(2022, 7, 13, 5, 57, 0, 0, time.UTC)
+
+	return []*models.Job{
+		{
+			Jid:        "j1",
+			MultiJobID: "mj1",
+			ClientID:   "cl1",
+			ClientName: "web#1",
+			Command:    "uptime",
+			Status:     models.JobStatusSuccessful,
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(1500 * time.Millisecond),
+			Result:     models.JobResult{Stdout: "up 3 days\n"},
+		},
+		{
+			Jid:        "j2",
+			MultiJobID: "mj1",
+			ClientID:   "cl2",
+			Command:    "uptime",
+			Status:     models.JobStatusFailed,
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(250 * time.Millisecond),
+			Result:     models.JobResult{Stderr: "uptime: not found"},
+		},
+		{
+			Jid:        "j3",
+			MultiJobID: "mj1",
+			ClientID:   "cl3",
+			ClientName: "db",
+			Command:    "uptime",
+			Status:     models.JobStatusUnknown,
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(60 * time.Second),
+			Error:      "timeout",
+		},
+	}
+}
+
+func TestRenderJUnitReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := RenderJUnitReport(buf, time.Date(2022, 7, 13, 5, 56, 59, 0, time.UTC), reportTestJobs())
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="rportcli" tests="3" failures="1" errors="1" time="61.750">
+  <testsuite name="uptime" id="mj1" tests="3" failures="1" errors="1" skipped="0" timestamp="2022-07-13T05:56:59Z" time="61.750">
+    <testcase name="web#1" classname="cl1" time="1.500">
+      <system-out>up 3 days&#xA;</system-out>
+    </testcase>
+    <testcase name="cl2" classname="cl2" time="0.250">
+      <failure message="job failed" type="failed">uptime: not found</failure>
+      <system-err>uptime: not found</system-err>
+    </testcase>
+    <testcase name="db" classname="cl3" time="60.000">
+      <error message="job timed out" type="unknown">timeout</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderTAPReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := RenderTAPReport(buf, reportTestJobs())
+	require.NoError(t, err)
+
+	expected := `TAP version 13
+1..3
+ok 1 - web\#1
+  ---
+  client_id: cl1
+  jid: j1
+  status: successful
+  duration_sec: 1.5
+  stdout: |
+      up 3 days
+  ...
+not ok 2 - cl2
+  ---
+  client_id: cl2
+  jid: j2
+  status: failed
+  duration_sec: 0.25
+  stderr: 'uptime: not found'
+  ...
+not ok 3 - db
+  ---
+  client_id: cl3
+  jid: j3
+  status: unknown
+  duration_sec: 60
+  error: timeout
+  ...
+`
+	assert.Equal(t, expected, buf.String())
+}
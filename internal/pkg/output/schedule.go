@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type ScheduleRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (sr *ScheduleRenderer) RenderSchedules(schedules []*models.Schedule) error {
+	return RenderByFormat(
+		sr.Format,
+		sr.Writer,
+		schedules,
+		func() error {
+			err := RenderHeader(sr.Writer, "Schedules")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(schedules))
+			for _, s := range schedules {
+				rowProviders = append(rowProviders, s)
+			}
+
+			return RenderTable(sr.Writer, &models.Schedule{}, rowProviders, sr.ColCountCalculator)
+		},
+	)
+}
+
+func (sr *ScheduleRenderer) RenderSchedule(s *models.Schedule) error {
+	return RenderByFormat(
+		sr.Format,
+		sr.Writer,
+		s,
+		func() error {
+			if s == nil {
+				return nil
+			}
+			err := RenderHeader(sr.Writer, fmt.Sprintf("Schedule [%s]\n", s.Name))
+			if err != nil {
+				return err
+			}
+			RenderKeyValues(sr.Writer, s)
+			return nil
+		},
+	)
+}
+
+// RenderFireTimes renders the upcoming fire times of a schedule before it's submitted. They are only rendered
+// in the human format, so that the machine readable output only contains the submitted schedule.
+func (sr *ScheduleRenderer) RenderFireTimes(times []time.Time) error {
+	if sr.Format != "" && sr.Format != FormatHuman {
+		return nil
+	}
+
+	rowProviders := make([]RowData, 0, len(times))
+	for _, t := range times {
+		rowProviders = append(rowProviders, &models.FireTime{Time: t})
+	}
+
+	err := RenderTable(sr.Writer, &models.FireTime{}, rowProviders, sr.ColCountCalculator)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(sr.Writer)
+	return err
+}
+
+func (sr *ScheduleRenderer) RenderDelete(s KvProvider) error {
+	return RenderByFormat(
+		sr.Format,
+		sr.Writer,
+		s,
+		func() error {
+			RenderKeyValues(sr.Writer, s)
+			return nil
+		},
+	)
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderSchedules(t *testing.T) {
+	schedules := []*models.Schedule{
+		{
+			ID:        "s1",
+			Name:      "nightly-cleanup",
+			Schedule:  "0 3 * * *",
+			ClientIDs: []string{"c1", "c2"},
+			Command:   "rm -rf /tmp/cache",
+		},
+	}
+
+	buf := bytes.Buffer{}
+	sr := &ScheduleRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := sr.RenderSchedules(schedules)
+	require.NoError(t, err)
+	assert.Equal(t, `Schedules
+ID NAME            SCHEDULE  CLIENTS COMMAND           
+s1 nightly-cleanup 0 3 * * * 2       rm -rf /tmp/cache 
+`, buf.String())
+}
+
+func TestRenderFireTimes(t *testing.T) {
+	times := []time.Time{
+		time.Date(2022, 3, 2, 3, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 3, 3, 0, 0, 0, time.UTC),
+	}
+
+	buf := bytes.Buffer{}
+	sr := &ScheduleRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := sr.RenderFireTimes(times)
+	require.NoError(t, err)
+	assert.Equal(t, `NEXT FIRE TIME       
+2022-03-02T03:00:00Z 
+2022-03-03T03:00:00Z 
+
+`, buf.String())
+
+	buf.Reset()
+	sr.Format = FormatJSON
+	err = sr.RenderFireTimes(times)
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
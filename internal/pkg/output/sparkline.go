@@ -0,0 +1,62 @@
+package output
+
+import (
+	"math"
+)
+
+var sparklineBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the values as a line of unicode bars between the smallest and the largest value,
+// if there are more values than the given width, neighbouring values are averaged
+func Sparkline(values []float64, width int) string {
+	values = downsample(values, width)
+	if len(values) == 0 {
+		return ""
+	}
+
+	minValue, _, maxValue := MinAvgMax(values)
+	valueRange := maxValue - minValue
+
+	line := make([]rune, 0, len(values))
+	for _, v := range values {
+		barIndex := 0
+		if valueRange > 0 {
+			barIndex = int(math.Round((v - minValue) / valueRange * float64(len(sparklineBars)-1)))
+		}
+		line = append(line, sparklineBars[barIndex])
+	}
+
+	return string(line)
+}
+
+// MinAvgMax returns the smallest, the average and the largest value, zeros are returned for no values
+func MinAvgMax(values []float64) (minValue, avgValue, maxValue float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+
+	minValue, maxValue = values[0], values[0]
+	sum := 0.0
+	for _, v := range values {
+		minValue = math.Min(minValue, v)
+		maxValue = math.Max(maxValue, v)
+		sum += v
+	}
+
+	return minValue, sum / float64(len(values)), maxValue
+}
+
+func downsample(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+
+	buckets := make([]float64, 0, width)
+	for i := 0; i < width; i++ {
+		bucket := values[i*len(values)/width : (i+1)*len(values)/width]
+		_, avg, _ := MinAvgMax(bucket)
+		buckets = append(buckets, avg)
+	}
+
+	return buckets
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Values         []float64
+		Width          int
+		ExpectedOutput string
+	}{
+		{
+			Name:           "empty",
+			Values:         []float64{},
+			Width:          10,
+			ExpectedOutput: "",
+		},
+		{
+			Name:           "rising",
+			Values:         []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			Width:          10,
+			ExpectedOutput: "▁▂▃▄▅▆▇█",
+		},
+		{
+			Name:           "constant",
+			Values:         []float64{5, 5, 5},
+			Width:          10,
+			ExpectedOutput: "▁▁▁",
+		},
+		{
+			Name:           "downsampled",
+			Values:         []float64{0, 0, 10, 10, 0, 0},
+			Width:          3,
+			ExpectedOutput: "▁█▁",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedOutput, Sparkline(tc.Values, tc.Width))
+		})
+	}
+}
+
+func TestMinAvgMax(t *testing.T) {
+	minValue, avgValue, maxValue := MinAvgMax([]float64{3, 1, 8})
+	assert.Equal(t, 1.0, minValue)
+	assert.Equal(t, 4.0, avgValue)
+	assert.Equal(t, 8.0, maxValue)
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type StoredCommandRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (scr *StoredCommandRenderer) RenderStoredCommands(commands []*models.StoredCommand) error {
+	return RenderByFormat(
+		scr.Format,
+		scr.Writer,
+		commands,
+		func() error {
+			err := RenderHeader(scr.Writer, "Stored Commands")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(commands))
+			for _, sc := range commands {
+				rowProviders = append(rowProviders, sc)
+			}
+
+			return RenderTable(scr.Writer, &models.StoredCommand{}, rowProviders, scr.ColCountCalculator)
+		},
+	)
+}
+
+func (scr *StoredCommandRenderer) RenderStoredCommand(sc *models.StoredCommand) error {
+	return RenderByFormat(
+		scr.Format,
+		scr.Writer,
+		sc,
+		func() error {
+			if sc == nil {
+				return nil
+			}
+			err := RenderHeader(scr.Writer, fmt.Sprintf("Stored Command [%s]\n", sc.Name))
+			if err != nil {
+				return err
+			}
+			RenderKeyValues(scr.Writer, sc)
+			return nil
+		},
+	)
+}
+
+func (scr *StoredCommandRenderer) RenderDelete(s KvProvider) error {
+	return RenderByFormat(
+		scr.Format,
+		scr.Writer,
+		s,
+		func() error {
+			RenderKeyValues(scr.Writer, s)
+			return nil
+		},
+	)
+}
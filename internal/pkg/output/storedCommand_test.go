@@ -0,0 +1,40 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderStoredCommands(t *testing.T) {
+	commands := []*models.StoredCommand{
+		{
+			ID:        "sc1",
+			Name:      "disk-usage",
+			Cmd:       "df -h /",
+			UpdatedBy: "admin",
+			UpdatedAt: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	buf := bytes.Buffer{}
+	scr := &StoredCommandRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := scr.RenderStoredCommands(commands)
+	require.NoError(t, err)
+	assert.Equal(t, `Stored Commands
+ID  NAME       UPDATED BY UPDATED AT           COMMAND 
+sc1 disk-usage admin      2022-03-01T12:00:00Z df -h / 
+`, buf.String())
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+type StoredScriptRenderer struct {
+	ColCountCalculator CalcTerminalColumnsCount
+	Writer             io.Writer
+	Format             string
+}
+
+func (ssr *StoredScriptRenderer) RenderStoredScripts(scripts []*models.StoredScript) error {
+	return RenderByFormat(
+		ssr.Format,
+		ssr.Writer,
+		scripts,
+		func() error {
+			err := RenderHeader(ssr.Writer, "Stored Scripts")
+			if err != nil {
+				return err
+			}
+
+			rowProviders := make([]RowData, 0, len(scripts))
+			for _, ss := range scripts {
+				rowProviders = append(rowProviders, ss)
+			}
+
+			return RenderTable(ssr.Writer, &models.StoredScript{}, rowProviders, ssr.ColCountCalculator)
+		},
+	)
+}
+
+// RenderStoredScript renders the properties of a stored script followed by the script itself
+func (ssr *StoredScriptRenderer) RenderStoredScript(ss *models.StoredScript) error {
+	return RenderByFormat(
+		ssr.Format,
+		ssr.Writer,
+		ss,
+		func() error {
+			if ss == nil {
+				return nil
+			}
+			err := RenderHeader(ssr.Writer, fmt.Sprintf("Stored Script [%s]\n", ss.Name))
+			if err != nil {
+				return err
+			}
+			RenderKeyValues(ssr.Writer, ss)
+
+			_, err = fmt.Fprintf(ssr.Writer, "\nScript:\n\n%s\n", ss.Script)
+			return err
+		},
+	)
+}
+
+func (ssr *StoredScriptRenderer) RenderDelete(s KvProvider) error {
+	return RenderByFormat(
+		ssr.Format,
+		ssr.Writer,
+		s,
+		func() error {
+			RenderKeyValues(ssr.Writer, s)
+			return nil
+		},
+	)
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudradar-monitoring/rportcli/internal/pkg/models"
+)
+
+func TestRenderStoredScript(t *testing.T) {
+	ss := &models.StoredScript{
+		ID:          "ss1",
+		Name:        "cleanup",
+		Script:      "#!/bin/sh\nrm -rf /tmp/cache",
+		Interpreter: "sh",
+		Cwd:         "/tmp",
+		IsSudo:      true,
+		CreatedBy:   "admin",
+		CreatedAt:   time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedBy:   "admin",
+		UpdatedAt:   time.Date(2022, 3, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	buf := bytes.Buffer{}
+	ssr := &StoredScriptRenderer{
+		ColCountCalculator: func() int {
+			return 150
+		},
+		Writer: &buf,
+		Format: FormatHuman,
+	}
+
+	err := ssr.RenderStoredScript(ss)
+	require.NoError(t, err)
+	assert.Equal(t, `Stored Script [cleanup]
+
+KEY          VALUE                
+ID:          ss1                  
+Name:        cleanup              
+Interpreter: sh                   
+Cwd:         /tmp                 
+Is sudo:     true                 
+Created By:  admin                
+Created At:  2022-03-01T12:00:00Z 
+Updated By:  admin                
+Updated At:  2022-03-02T12:00:00Z 
+
+Script:
+
+#!/bin/sh
+rm -rf /tmp/cache
+`, buf.String())
+}
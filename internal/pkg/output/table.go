@@ -85,6 +85,13 @@ type KvProvider interface {
 	KeyValues() []testing.KeyValueStr
 }
 
+// keyValues provides key values prepared for rendering, e.g. marked by colors
+type keyValues []testing.KeyValueStr
+
+func (kv keyValues) KeyValues() []testing.KeyValueStr {
+	return kv
+}
+
 func RenderTable(rw io.Writer, col ColumnsData, rowProviders []RowData, calc CalcTerminalColumnsCount) error {
 	table := buildTable(rw)
 
@@ -0,0 +1,236 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears limits the search for the next fire time of expressions which never or rarely match, e.g. 0 0 30 2 *
+const cronSearchYears = 5
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as sunday as well and mapped to 0
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is a parsed cron expression with the fields minute, hour, day of month, month and day of week
+// or one of the descriptors like @daily or @every 1h30m as understood by the rport server
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// a day has to match both day fields if one of them is *, otherwise it has to match either of them
+	domStar, dowStar bool
+	every            time.Duration
+}
+
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("empty cron expression")
+	}
+
+	if strings.HasPrefix(expr, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("invalid cron expression %q: @every needs a positive duration like 1h30m", expr)
+		}
+		if every < time.Second {
+			every = time.Second
+		}
+		return &CronSchedule{every: every.Truncate(time.Second)}, nil
+	}
+
+	if strings.HasPrefix(expr, "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron expression %q: unknown descriptor", expr)
+		}
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf(
+			"invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d",
+			expr,
+			len(fields),
+		)
+	}
+
+	cs := &CronSchedule{}
+	var err error
+	parsers := []struct {
+		target *uint64
+		field  cronField
+		star   *bool
+	}{
+		{&cs.minute, cronMinute, nil},
+		{&cs.hour, cronHour, nil},
+		{&cs.dom, cronDom, &cs.domStar},
+		{&cs.month, cronMonth, nil},
+		{&cs.dow, cronDow, &cs.dowStar},
+	}
+	for i, p := range parsers {
+		*p.target, err = parseCronField(fields[i], p.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+		if p.star != nil {
+			*p.star = fields[i] == "*" || fields[i] == "?"
+		}
+	}
+	if cs.dow&(1<<7) != 0 {
+		cs.dow |= 1
+	}
+
+	return cs, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps like 1,5-10,*/15 into a bit set
+func parseCronField(s string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", part[i+1:], f.name)
+			}
+		}
+
+		start, end, err := parseCronRange(rangeExpr, f)
+		if err != nil {
+			return 0, err
+		}
+		if strings.Contains(part, "/") && !strings.Contains(rangeExpr, "-") && rangeExpr != "*" && rangeExpr != "?" {
+			// 5/15 means every 15 starting at 5
+			end = f.max
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronRange(s string, f cronField) (start, end int, err error) {
+	if s == "*" || s == "?" {
+		return f.min, f.max, nil
+	}
+
+	bounds := strings.SplitN(s, "-", 2)
+	start, err = parseCronValue(bounds[0], f)
+	if err != nil {
+		return 0, 0, err
+	}
+	end = start
+	if len(bounds) == 2 {
+		end, err = parseCronValue(bounds[1], f)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("invalid range %q in %s field", s, f.name)
+	}
+
+	return start, end, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d in %s field", v, f.min, f.max, f.name)
+	}
+
+	return v, nil
+}
+
+// Next returns the first fire time after the given time or the zero time if the expression never matches
+func (cs *CronSchedule) Next(after time.Time) time.Time {
+	if cs.every > 0 {
+		return after.Add(cs.every - time.Duration(after.Nanosecond())*time.Nanosecond)
+	}
+
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case cs.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !cs.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case cs.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case cs.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// NextN returns up to n fire times after the given time
+func (cs *CronSchedule) NextN(after time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		after = cs.Next(after)
+		if after.IsZero() {
+			break
+		}
+		times = append(times, after)
+	}
+
+	return times
+}
+
+func (cs *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := cs.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cs.dow&(1<<uint(t.Weekday())) != 0
+	if cs.domStar || cs.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
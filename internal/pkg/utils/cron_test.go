@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNextN(t *testing.T) {
+	// a Tuesday
+	now := time.Date(2022, 3, 1, 10, 17, 30, 0, time.UTC)
+
+	testCases := []struct {
+		Expr          string
+		ExpectedTimes []string
+	}{
+		{
+			Expr:          "*/15 * * * *",
+			ExpectedTimes: []string{"2022-03-01T10:30:00Z", "2022-03-01T10:45:00Z", "2022-03-01T11:00:00Z"},
+		},
+		{
+			Expr:          "0 2 * * mon-fri",
+			ExpectedTimes: []string{"2022-03-02T02:00:00Z", "2022-03-03T02:00:00Z", "2022-03-04T02:00:00Z"},
+		},
+		{
+			Expr:          "30 4 1,15 * 5",
+			ExpectedTimes: []string{"2022-03-04T04:30:00Z", "2022-03-11T04:30:00Z", "2022-03-15T04:30:00Z"},
+		},
+		{
+			Expr:          "0 0 29 2 *",
+			ExpectedTimes: []string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z"},
+		},
+		{
+			Expr:          "0 0 * * 7",
+			ExpectedTimes: []string{"2022-03-06T00:00:00Z", "2022-03-13T00:00:00Z", "2022-03-20T00:00:00Z"},
+		},
+		{
+			Expr:          "@monthly",
+			ExpectedTimes: []string{"2022-04-01T00:00:00Z", "2022-05-01T00:00:00Z", "2022-06-01T00:00:00Z"},
+		},
+		{
+			Expr:          "@every 1h30m",
+			ExpectedTimes: []string{"2022-03-01T11:47:30Z", "2022-03-01T13:17:30Z", "2022-03-01T14:47:30Z"},
+		},
+		{
+			Expr:          "0 0 30 2 *",
+			ExpectedTimes: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Expr, func(t *testing.T) {
+			cs, err := ParseCron(tc.Expr)
+			require.NoError(t, err)
+
+			actualTimes := make([]string, 0)
+			for _, next := range cs.NextN(now, 3) {
+				actualTimes = append(actualTimes, next.Format(time.RFC3339))
+			}
+			assert.Equal(t, tc.ExpectedTimes, actualTimes)
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	testCases := []struct {
+		Expr          string
+		ExpectedError string
+	}{
+		{
+			Expr:          "",
+			ExpectedError: "empty cron expression",
+		},
+		{
+			Expr:          "* * * *",
+			ExpectedError: `invalid cron expression "* * * *": expected 5 fields (minute hour day-of-month month day-of-week), got 4`,
+		},
+		{
+			Expr:          "60 * * * *",
+			ExpectedError: `invalid cron expression "60 * * * *": value 60 out of range 0-59 in minute field`,
+		},
+		{
+			Expr:          "0 0 * foo *",
+			ExpectedError: `invalid cron expression "0 0 * foo *": invalid value "foo" in month field`,
+		},
+		{
+			Expr:          "*/0 * * * *",
+			ExpectedError: `invalid cron expression "*/0 * * * *": invalid step "0" in minute field`,
+		},
+		{
+			Expr:          "0 5-2 * * *",
+			ExpectedError: `invalid cron expression "0 5-2 * * *": invalid range "5-2" in hour field`,
+		},
+		{
+			Expr:          "@sometimes",
+			ExpectedError: `invalid cron expression "@sometimes": unknown descriptor`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Expr, func(t *testing.T) {
+			_, err := ParseCron(tc.Expr)
+			assert.EqualError(t, err, tc.ExpectedError)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// ParseDuration works like time.ParseDuration but additionally accepts a number of days with a 'd' suffix, e.g. 30d
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasSuffix(s, "d") {
+		return time.ParseDuration(s)
+	}
+
+	days, err := strconv.ParseFloat(strings.TrimSuffix(s, "d"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return time.Duration(days * float64(day)), nil
+}
+
+// ParseTimeOrAgo parses an RFC3339 timestamp or a duration like 2h or 7d which is subtracted from now
+func ParseTimeOrAgo(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	ago, err := ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected an RFC3339 timestamp or a duration like 2h or 7d", s)
+	}
+
+	return now.Add(-ago), nil
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		Input            string
+		ExpectedDuration time.Duration
+		ExpectedError    string
+	}{
+		{
+			Input:            "30d",
+			ExpectedDuration: 30 * 24 * time.Hour,
+		},
+		{
+			Input:            "1.5d",
+			ExpectedDuration: 36 * time.Hour,
+		},
+		{
+			Input:            "12h",
+			ExpectedDuration: 12 * time.Hour,
+		},
+		{
+			Input:         "xd",
+			ExpectedError: `invalid duration "xd"`,
+		},
+		{
+			Input:         "12",
+			ExpectedError: `time: missing unit in duration "12"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			actualDuration, err := ParseDuration(tc.Input)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedDuration, actualDuration)
+		})
+	}
+}
+
+func TestParseTimeOrAgo(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	actualTime, err := ParseTimeOrAgo("2h", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC), actualTime)
+
+	actualTime, err = ParseTimeOrAgo("2022-02-01T00:00:00+01:00", now)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2022, 1, 31, 23, 0, 0, 0, time.UTC).Equal(actualTime))
+
+	_, err = ParseTimeOrAgo("yesterday", now)
+	assert.EqualError(t, err, `invalid time "yesterday", expected an RFC3339 timestamp or a duration like 2h or 7d`)
+}
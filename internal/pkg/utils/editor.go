@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// OpenEditor opens the file in the editor given by $VISUAL or $EDITOR and waits until it's closed,
+// without any of them vi or notepad on windows is used
+func OpenEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// the editor variables may contain arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		return errors.New("no editor configured, set $EDITOR")
+	}
+
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
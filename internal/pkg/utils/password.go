@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const passwordAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// GeneratePassword returns a cryptographically random password of the given length using letters and digits only,
+// so it can be pasted into config files and shells without quoting
+func GeneratePassword(length int) (string, error) {
+	alphabetLen := big.NewInt(int64(len(passwordAlphabet)))
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+
+	return string(password), nil
+}
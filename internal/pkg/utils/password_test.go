@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	password1, err := GeneratePassword(32)
+	require.NoError(t, err)
+	password2, err := GeneratePassword(32)
+	require.NoError(t, err)
+
+	assert.Regexp(t, regexp.MustCompile(`^[a-zA-Z0-9]{32}$`), password1)
+	assert.NotEqual(t, password1, password2)
+}
@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const selectPromptMaxVisible = 10
+
+var ErrSelectionAborted = errors.New("selection aborted")
+
+var (
+	keyUp        = [][]byte{{27, '[', 'A'}, {27, 'O', 'A'}}
+	keyDown      = [][]byte{{27, '[', 'B'}, {27, 'O', 'B'}}
+	keyCtrlC     = byte(3)
+	keyEscape    = byte(27)
+	keyDelete    = byte(127)
+	keyBackspace = byte(8)
+)
+
+// IsTerminal tells if the file, e.g. os.Stdin, is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// SelectPrompt lets the user choose one of several items with the arrow keys, typing filters the items
+type SelectPrompt struct {
+	In  *os.File
+	Out io.Writer
+}
+
+// Select returns the index of the chosen item, ErrSelectionAborted is returned on Ctrl+C or Esc
+func (sp *SelectPrompt) Select(title string, items []string) (int, error) {
+	fd := int(sp.In.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return -1, err
+	}
+	defer func() {
+		_ = term.Restore(fd, oldState)
+	}()
+
+	state := newSelectState(items)
+	drawnLines := 0
+	input := make([]byte, 32)
+	for {
+		drawnLines, err = sp.draw(title, state, drawnLines)
+		if err != nil {
+			return -1, err
+		}
+
+		n, err := sp.In.Read(input)
+		if err != nil {
+			return -1, err
+		}
+
+		done, err := state.handleInput(input[:n])
+		if err != nil || done {
+			sp.erase(drawnLines)
+			if err != nil {
+				return -1, err
+			}
+			return state.selected(), nil
+		}
+	}
+}
+
+func (sp *SelectPrompt) draw(title string, state *selectState, drawnLines int) (int, error) {
+	sp.erase(drawnLines)
+
+	maxWidth := CalcTerminalColumnsCount() - 1
+	lines := []string{
+		title,
+		"Filter: " + string(state.filter),
+	}
+	if len(state.matches) == 0 {
+		lines = append(lines, "  no matches")
+	}
+	from, to := state.visible()
+	for i := from; i < to; i++ {
+		prefix := "  "
+		if i == state.cursor {
+			prefix = "> "
+		}
+		lines = append(lines, truncate(prefix+state.items[state.matches[i]], maxWidth))
+	}
+	lines = append(lines, fmt.Sprintf("(%d/%d) arrow keys to move, type to filter, enter to select, esc to cancel", len(state.matches), len(state.items)))
+
+	_, err := io.WriteString(sp.Out, strings.Join(lines, "\r\n"))
+
+	return len(lines), err
+}
+
+// erase moves the cursor to the first drawn line and clears everything below
+func (sp *SelectPrompt) erase(drawnLines int) {
+	if drawnLines == 0 {
+		return
+	}
+	moveUp := ""
+	if drawnLines > 1 {
+		moveUp = fmt.Sprintf("\x1b[%dA", drawnLines-1)
+	}
+	_, _ = io.WriteString(sp.Out, "\r"+moveUp+"\x1b[J")
+}
+
+func truncate(s string, maxWidth int) string {
+	if maxWidth <= 0 || utf8.RuneCountInString(s) <= maxWidth {
+		return s
+	}
+	return string([]rune(s)[:maxWidth])
+}
+
+type selectState struct {
+	items   []string
+	filter  []rune
+	matches []int
+	cursor  int
+	offset  int
+}
+
+func newSelectState(items []string) *selectState {
+	s := &selectState{items: items}
+	s.applyFilter()
+	return s
+}
+
+func (s *selectState) applyFilter() {
+	filter := strings.ToLower(string(s.filter))
+	s.matches = s.matches[:0]
+	for i, item := range s.items {
+		if strings.Contains(strings.ToLower(item), filter) {
+			s.matches = append(s.matches, i)
+		}
+	}
+	s.cursor = 0
+	s.offset = 0
+}
+
+// handleInput applies the pressed keys, done is true when an item is chosen
+func (s *selectState) handleInput(input []byte) (done bool, err error) {
+	if len(input) == 0 {
+		return false, nil
+	}
+
+	switch {
+	case matchesKey(input, keyUp):
+		s.moveCursor(-1)
+	case matchesKey(input, keyDown):
+		s.moveCursor(1)
+	case input[0] == '\r' || input[0] == '\n':
+		return len(s.matches) > 0, nil
+	case input[0] == keyCtrlC || (input[0] == keyEscape && len(input) == 1):
+		return false, ErrSelectionAborted
+	case input[0] == keyDelete || input[0] == keyBackspace:
+		if len(s.filter) > 0 {
+			s.filter = s.filter[:len(s.filter)-1]
+			s.applyFilter()
+		}
+	case input[0] == keyEscape:
+		// other escape sequences like left and right arrows are ignored
+	default:
+		changed := false
+		for _, r := range string(input) {
+			if unicode.IsPrint(r) {
+				s.filter = append(s.filter, r)
+				changed = true
+			}
+		}
+		if changed {
+			s.applyFilter()
+		}
+	}
+
+	return false, nil
+}
+
+func (s *selectState) moveCursor(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+
+	s.cursor = (s.cursor + delta + len(s.matches)) % len(s.matches)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+selectPromptMaxVisible {
+		s.offset = s.cursor - selectPromptMaxVisible + 1
+	}
+}
+
+func (s *selectState) visible() (from, to int) {
+	to = s.offset + selectPromptMaxVisible
+	if to > len(s.matches) {
+		to = len(s.matches)
+	}
+	return s.offset, to
+}
+
+// selected returns the index of the item under the cursor or -1 if no item matches the filter
+func (s *selectState) selected() int {
+	if len(s.matches) == 0 {
+		return -1
+	}
+	return s.matches[s.cursor]
+}
+
+func matchesKey(input []byte, keys [][]byte) bool {
+	for _, key := range keys {
+		if bytes.Equal(input, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectStateNavigation(t *testing.T) {
+	s := newSelectState([]string{"web1", "web2", "db1"})
+
+	done, err := s.handleInput([]byte{27, '[', 'B'})
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, 1, s.selected())
+
+	_, _ = s.handleInput([]byte{27, '[', 'B'})
+	_, _ = s.handleInput([]byte{27, '[', 'B'})
+	assert.Equal(t, 0, s.selected(), "moving down from the last item wraps around")
+
+	_, _ = s.handleInput([]byte{27, '[', 'A'})
+	assert.Equal(t, 2, s.selected(), "moving up from the first item wraps around")
+
+	done, err = s.handleInput([]byte{'\r'})
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, 2, s.selected())
+}
+
+func TestSelectStateFilter(t *testing.T) {
+	s := newSelectState([]string{"web1", "WEB2", "db1"})
+
+	_, _ = s.handleInput([]byte("we"))
+	assert.Equal(t, []int{0, 1}, s.matches)
+
+	_, _ = s.handleInput([]byte("x"))
+	assert.Empty(t, s.matches)
+	assert.Equal(t, -1, s.selected())
+
+	done, err := s.handleInput([]byte{'\r'})
+	require.NoError(t, err)
+	assert.False(t, done, "enter is ignored if nothing matches")
+
+	_, _ = s.handleInput([]byte{127})
+	_, _ = s.handleInput([]byte{27, '[', 'B'})
+	assert.Equal(t, 1, s.selected())
+}
+
+func TestSelectStateAbort(t *testing.T) {
+	s := newSelectState([]string{"web1"})
+
+	_, err := s.handleInput([]byte{3})
+	assert.ErrorIs(t, err, ErrSelectionAborted)
+
+	_, err = s.handleInput([]byte{27})
+	assert.ErrorIs(t, err, ErrSelectionAborted)
+}
+
+func TestSelectStateScrolling(t *testing.T) {
+	items := make([]string, 15)
+	for i := range items {
+		items[i] = "client"
+	}
+	s := newSelectState(items)
+
+	for i := 0; i < 12; i++ {
+		_, _ = s.handleInput([]byte{27, '[', 'B'})
+	}
+	from, to := s.visible()
+	assert.Equal(t, 3, from)
+	assert.Equal(t, 13, to)
+	assert.Equal(t, 12, s.selected())
+}
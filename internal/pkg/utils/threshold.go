@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Threshold is a number of items like 3 or a percentage of all items like 10%
+type Threshold struct {
+	count     int
+	percent   float64
+	isPercent bool
+}
+
+func ParseThreshold(s string) (*Threshold, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return &Threshold{}, nil
+	}
+
+	if strings.HasSuffix(s, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return nil, fmt.Errorf("invalid threshold %q: expected a percentage between 0%% and 100%%", s)
+		}
+		return &Threshold{percent: percent, isPercent: true}, nil
+	}
+
+	count, err := strconv.Atoi(s)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid threshold %q: expected a non-negative number like 3 or a percentage like 10%%", s)
+	}
+
+	return &Threshold{count: count}, nil
+}
+
+// Exceeded tells if the number of items is above the threshold, a percentage is taken of the total
+func (t *Threshold) Exceeded(n, total int) bool {
+	if t.isPercent {
+		return float64(n)*100 > t.percent*float64(total)
+	}
+
+	return n > t.count
+}
+
+// Count returns the number of items of the threshold, a percentage is taken of the total and rounded up
+func (t *Threshold) Count(total int) int {
+	if t.isPercent {
+		return int(math.Ceil(t.percent * float64(total) / 100))
+	}
+
+	return t.count
+}
+
+func (t *Threshold) String() string {
+	if t.isPercent {
+		return strconv.FormatFloat(t.percent, 'f', -1, 64) + "%"
+	}
+
+	return strconv.Itoa(t.count)
+}
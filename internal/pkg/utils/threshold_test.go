@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdExceeded(t *testing.T) {
+	testCases := []struct {
+		Threshold        string
+		N, Total         int
+		ExpectedExceeded bool
+	}{
+		{Threshold: "", N: 0, Total: 10, ExpectedExceeded: false},
+		{Threshold: "", N: 1, Total: 10, ExpectedExceeded: true},
+		{Threshold: "2", N: 2, Total: 10, ExpectedExceeded: false},
+		{Threshold: "2", N: 3, Total: 10, ExpectedExceeded: true},
+		{Threshold: "10%", N: 1, Total: 10, ExpectedExceeded: false},
+		{Threshold: "10%", N: 2, Total: 10, ExpectedExceeded: true},
+		{Threshold: "12.5 %", N: 1, Total: 8, ExpectedExceeded: false},
+		{Threshold: "0%", N: 1, Total: 300, ExpectedExceeded: true},
+		{Threshold: "100%", N: 5, Total: 5, ExpectedExceeded: false},
+	}
+
+	for _, tc := range testCases {
+		th, err := ParseThreshold(tc.Threshold)
+		require.NoError(t, err, tc.Threshold)
+		assert.Equal(t, tc.ExpectedExceeded, th.Exceeded(tc.N, tc.Total), "%s with %d of %d", tc.Threshold, tc.N, tc.Total)
+	}
+}
+
+func TestThresholdCount(t *testing.T) {
+	testCases := []struct {
+		Threshold     string
+		Total         int
+		ExpectedCount int
+	}{
+		{Threshold: "3", Total: 10, ExpectedCount: 3},
+		{Threshold: "3", Total: 2, ExpectedCount: 3},
+		{Threshold: "10%", Total: 50, ExpectedCount: 5},
+		{Threshold: "10%", Total: 51, ExpectedCount: 6},
+		{Threshold: "0.5%", Total: 10, ExpectedCount: 1},
+		{Threshold: "0%", Total: 10, ExpectedCount: 0},
+	}
+
+	for _, tc := range testCases {
+		th, err := ParseThreshold(tc.Threshold)
+		require.NoError(t, err, tc.Threshold)
+		assert.Equal(t, tc.ExpectedCount, th.Count(tc.Total), "%s of %d", tc.Threshold, tc.Total)
+	}
+}
+
+func TestParseInvalidThreshold(t *testing.T) {
+	for _, s := range []string{"-1", "abc", "101%", "-5%", "x%", "1.5"} {
+		_, err := ParseThreshold(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/cloudradar-monitoring/rportcli/cmd"
@@ -12,12 +14,17 @@ import (
 func main() {
 	err := cmd.Execute()
 	if err != nil {
-		if err == controllers.ErrNoClientIDsToUse {
+		exitErr := &controllers.ExitError{}
+		switch {
+		case err == controllers.ErrNoClientIDsToUse:
 			// no client ids means no work, so exit with a regular message and a non-error code.
 			msg := err.Error()
 			displayMsg := strings.ToUpper(msg[:1]) + msg[1:]
 			fmt.Println(displayMsg)
-		} else {
+		case errors.As(err, &exitErr):
+			logrus.Error(exitErr)
+			os.Exit(exitErr.Code)
+		default:
 			logrus.Fatal(err)
 		}
 	}